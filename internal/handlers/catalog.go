@@ -360,7 +360,7 @@ func (a *App) CreateCatalogProduct(r *fastglue.Request) error {
 	productInput := &whatsapp.ProductInput{
 		Name:        req.Name,
 		Price:       req.Price,
-		Currency:    req.Currency,
+		Currency:    whatsapp.Currency(req.Currency),
 		URL:         req.URL,
 		ImageURL:    req.ImageURL,
 		RetailerID:  req.RetailerID,
@@ -457,7 +457,7 @@ func (a *App) UpdateCatalogProduct(r *fastglue.Request) error {
 	productInput := &whatsapp.ProductInput{
 		Name:        req.Name,
 		Price:       req.Price,
-		Currency:    req.Currency,
+		Currency:    whatsapp.Currency(req.Currency),
 		URL:         req.URL,
 		ImageURL:    req.ImageURL,
 		Description: req.Description,
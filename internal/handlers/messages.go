@@ -136,15 +136,15 @@ func (a *App) SendOutgoingMessage(ctx context.Context, req OutgoingMessageReques
 	sendFn := func(sendCtx context.Context) (string, error) {
 		waAccount := a.toWhatsAppAccount(req.Account)
 
-		// Get reply-to message ID if this is a reply
-		var replyToMsgID string
+		// Send as a reply if this message quotes another one
+		var opts []whatsapp.SendOption
 		if req.ReplyToMessage != nil && req.ReplyToMessage.WhatsAppMessageID != "" {
-			replyToMsgID = req.ReplyToMessage.WhatsAppMessageID
+			opts = append(opts, whatsapp.WithReplyTo(req.ReplyToMessage.WhatsAppMessageID))
 		}
 
 		switch req.Type {
 		case models.MessageTypeText:
-			return a.WhatsApp.SendTextMessage(sendCtx, waAccount, req.Contact.PhoneNumber, req.Content, replyToMsgID)
+			return a.WhatsApp.SendTextMessage(sendCtx, waAccount, req.Contact.PhoneNumber, req.Content, opts...)
 
 		case models.MessageTypeImage, models.MessageTypeVideo, models.MessageTypeAudio, models.MessageTypeDocument:
 			// Upload media if MediaData is provided and MediaID is not set
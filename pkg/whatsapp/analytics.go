@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // AnalyticsRequest represents parameters for fetching analytics from Meta API
@@ -16,6 +17,8 @@ type AnalyticsRequest struct {
 	PhoneNumbers []string `json:"phone_numbers"` // Optional filter by phone numbers
 	TemplateIDs  []string `json:"template_ids"`  // Optional filter for template analytics
 	CountryCodes []string `json:"country_codes"` // Optional filter by country codes
+	// Dimensions breaks conversation analytics down, e.g. by ConversationDimensionCategory
+	Dimensions []string `json:"dimensions"`
 }
 
 // AnalyticsType represents the type of analytics to fetch
@@ -26,6 +29,16 @@ const (
 	AnalyticsTypePricing   AnalyticsType = "pricing_analytics"
 	AnalyticsTypeTemplate  AnalyticsType = "template_analytics"
 	AnalyticsTypeCall      AnalyticsType = "call_analytics"
+	// AnalyticsTypeConversation is Meta's conversation-based pricing analytics
+	AnalyticsTypeConversation AnalyticsType = "conversation_analytics"
+)
+
+// Conversation analytics dimensions accepted in AnalyticsRequest.Dimensions
+const (
+	ConversationDimensionCategory = "CONVERSATION_CATEGORY"
+	ConversationDimensionType     = "CONVERSATION_TYPE"
+	ConversationDimensionCountry  = "COUNTRY"
+	ConversationDimensionPhone    = "PHONE"
 )
 
 // MessagingAnalyticsDataPoint represents a single data point for messaging analytics
@@ -116,6 +129,15 @@ type TemplateAnalyticsDataPoint struct {
 	Cost       []TemplateCostItem  `json:"cost,omitempty"`
 }
 
+// Clicks returns the total number of button clicks across all buttons
+func (p TemplateAnalyticsDataPoint) Clicks() int64 {
+	var total int64
+	for _, click := range p.Clicked {
+		total += click.Count
+	}
+	return total
+}
+
 // TemplateAnalyticsDataEntry represents one entry in the data array
 type TemplateAnalyticsDataEntry struct {
 	Granularity string                       `json:"granularity"`
@@ -164,6 +186,35 @@ type CallAnalytics struct {
 	DataPoints  []CallAnalyticsDataPoint `json:"data_points"`
 }
 
+// ConversationAnalyticsDataPoint is a single bucket of conversation analytics.
+// The breakdown fields are set for the dimensions that were requested.
+type ConversationAnalyticsDataPoint struct {
+	Start                int64   `json:"start"`
+	End                  int64   `json:"end"`
+	Conversation         int64   `json:"conversation"` // Number of conversations
+	Cost                 float64 `json:"cost"`         // Cost in account currency
+	PhoneNumber          string  `json:"phone_number,omitempty"`
+	Country              string  `json:"country,omitempty"`
+	ConversationType     string  `json:"conversation_type,omitempty"`     // FREE_ENTRY, FREE_TIER, REGULAR
+	ConversationCategory string  `json:"conversation_category,omitempty"` // MARKETING, UTILITY, AUTHENTICATION, SERVICE
+}
+
+// ConversationAnalyticsEntry holds the data points of one entry in the data array
+type ConversationAnalyticsEntry struct {
+	DataPoints []ConversationAnalyticsDataPoint `json:"data_points"`
+}
+
+// ConversationAnalyticsRaw represents the raw response from Meta API
+type ConversationAnalyticsRaw struct {
+	Data []ConversationAnalyticsEntry `json:"data"`
+}
+
+// ConversationAnalytics represents conversation analytics response (flattened)
+type ConversationAnalytics struct {
+	Granularity string                           `json:"granularity"`
+	DataPoints  []ConversationAnalyticsDataPoint `json:"data_points"`
+}
+
 // MetaAnalyticsResponse is a generic response that holds any analytics type
 type MetaAnalyticsResponse struct {
 	ID                string              `json:"id"`
@@ -171,6 +222,8 @@ type MetaAnalyticsResponse struct {
 	PricingAnalytics  *PricingAnalytics   `json:"pricing_analytics,omitempty"`
 	TemplateAnalytics *TemplateAnalytics  `json:"template_analytics,omitempty"`
 	CallAnalytics     *CallAnalytics      `json:"call_analytics,omitempty"`
+	// ConversationAnalytics is set for AnalyticsTypeConversation
+	ConversationAnalytics *ConversationAnalytics `json:"conversation_analytics,omitempty"`
 }
 
 // metaAnalyticsRawResponse represents the raw response from Meta API
@@ -180,6 +233,8 @@ type metaAnalyticsRawResponse struct {
 	PricingAnalytics  json.RawMessage `json:"pricing_analytics,omitempty"`
 	TemplateAnalytics json.RawMessage `json:"template_analytics,omitempty"`
 	CallAnalytics     json.RawMessage `json:"call_analytics,omitempty"`
+	// ConversationAnalytics holds the conversation_analytics field
+	ConversationAnalytics json.RawMessage `json:"conversation_analytics,omitempty"`
 }
 
 // metaPagingCursors represents the cursors in Meta API pagination
@@ -205,7 +260,7 @@ func (c *Client) GetAnalytics(ctx context.Context, account *Account, analyticsTy
 	url := c.buildAnalyticsURL(account, analyticsType, req)
 	c.Log.Debug("Fetching Meta analytics", "type", analyticsType, "url", url)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch %s: %w", analyticsType, err)
 	}
@@ -290,11 +345,93 @@ func (c *Client) GetAnalytics(ctx context.Context, account *Account, analyticsTy
 			}
 			response.CallAnalytics = &analytics
 		}
+	case AnalyticsTypeConversation:
+		if len(rawResp.ConversationAnalytics) > 0 {
+			var rawAnalytics ConversationAnalyticsRaw
+			if err := json.Unmarshal(rawResp.ConversationAnalytics, &rawAnalytics); err != nil {
+				return nil, fmt.Errorf("failed to parse conversation analytics: %w", err)
+			}
+			// The response does not echo the granularity, so report the one requested
+			analytics := ConversationAnalytics{
+				Granularity: NormalizeGranularity(req.Granularity, analyticsType),
+				DataPoints:  make([]ConversationAnalyticsDataPoint, 0),
+			}
+			for _, entry := range rawAnalytics.Data {
+				analytics.DataPoints = append(analytics.DataPoints, entry.DataPoints...)
+			}
+			response.ConversationAnalytics = &analytics
+		}
 	}
 
 	return response, nil
 }
 
+// GetConversationAnalytics fetches conversation counts and costs for billing
+// reconciliation, broken down by req.Dimensions
+func (c *Client) GetConversationAnalytics(ctx context.Context, account *Account, req *AnalyticsRequest) (*ConversationAnalytics, error) {
+	if req.Start <= 0 || req.End <= req.Start {
+		return nil, fmt.Errorf("analytics end must be after start")
+	}
+	for _, dimension := range req.Dimensions {
+		switch dimension {
+		case ConversationDimensionCategory, ConversationDimensionType, ConversationDimensionCountry, ConversationDimensionPhone:
+		default:
+			return nil, fmt.Errorf("invalid conversation analytics dimension %q", dimension)
+		}
+	}
+
+	resp, err := c.GetAnalytics(ctx, account, AnalyticsTypeConversation, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ConversationAnalytics == nil {
+		return &ConversationAnalytics{
+			Granularity: NormalizeGranularity(req.Granularity, AnalyticsTypeConversation),
+			DataPoints:  []ConversationAnalyticsDataPoint{},
+		}, nil
+	}
+	return resp.ConversationAnalytics, nil
+}
+
+// maxTemplateAnalyticsIDs is the number of template IDs Meta accepts per template_analytics request
+const maxTemplateAnalyticsIDs = 10
+
+// GetTemplateAnalytics fetches daily sent/delivered/read counts and button clicks
+// for the given templates. IDs are requested in chunks of 10 as required by Meta;
+// an empty list returns every template with activity in the range.
+func (c *Client) GetTemplateAnalytics(ctx context.Context, account *Account, templateIDs []string, start, end time.Time) (*TemplateAnalytics, error) {
+	if !end.After(start) {
+		return nil, fmt.Errorf("analytics end must be after start")
+	}
+
+	chunks := [][]string{templateIDs}
+	if len(templateIDs) > maxTemplateAnalyticsIDs {
+		chunks = nil
+		for i := 0; i < len(templateIDs); i += maxTemplateAnalyticsIDs {
+			chunks = append(chunks, templateIDs[i:min(i+maxTemplateAnalyticsIDs, len(templateIDs))])
+		}
+	}
+
+	analytics := &TemplateAnalytics{
+		Granularity: "DAILY",
+		DataPoints:  make([]TemplateAnalyticsDataPoint, 0),
+	}
+	for _, ids := range chunks {
+		resp, err := c.GetAnalytics(ctx, account, AnalyticsTypeTemplate, &AnalyticsRequest{
+			Start:       start.Unix(),
+			End:         end.Unix(),
+			TemplateIDs: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if resp.TemplateAnalytics != nil {
+			analytics.DataPoints = append(analytics.DataPoints, resp.TemplateAnalytics.DataPoints...)
+		}
+	}
+	return analytics, nil
+}
+
 // buildAnalyticsURL builds the analytics endpoint URL with filters
 func (c *Client) buildAnalyticsURL(account *Account, analyticsType AnalyticsType, req *AnalyticsRequest) string {
 	// Template analytics uses a different endpoint format
@@ -332,6 +469,11 @@ func (c *Client) buildAnalyticsURL(account *Account, analyticsType AnalyticsType
 		filters = append(filters, "dimensions(PRICING_CATEGORY,PRICING_TYPE,COUNTRY)")
 	}
 
+	if len(req.Dimensions) > 0 && analyticsType == AnalyticsTypeConversation {
+		dimensionsJSON, _ := json.Marshal(req.Dimensions)
+		filters = append(filters, fmt.Sprintf("dimensions(%s)", string(dimensionsJSON)))
+	}
+
 	field := fmt.Sprintf("%s.%s", analyticsType, strings.Join(filters, "."))
 
 	return fmt.Sprintf("%s/%s/%s?fields=%s", c.getBaseURL(), account.APIVersion, account.BusinessID, field)
@@ -394,7 +536,7 @@ func NormalizeGranularity(granularity string, analyticsType AnalyticsType) strin
 	// Some endpoints use DAILY/MONTHLY format
 	useDailyFormat := false
 	switch analyticsType {
-	case AnalyticsTypePricing, AnalyticsTypeCall:
+	case AnalyticsTypePricing, AnalyticsTypeCall, AnalyticsTypeConversation:
 		useDailyFormat = true
 	}
 
@@ -413,7 +555,7 @@ func NormalizeGranularity(granularity string, analyticsType AnalyticsType) strin
 // ValidateAnalyticsType validates the analytics type value
 func ValidateAnalyticsType(analyticsType string) bool {
 	switch AnalyticsType(analyticsType) {
-	case AnalyticsTypeMessaging, AnalyticsTypePricing, AnalyticsTypeTemplate, AnalyticsTypeCall:
+	case AnalyticsTypeMessaging, AnalyticsTypePricing, AnalyticsTypeTemplate, AnalyticsTypeCall, AnalyticsTypeConversation:
 		return true
 	default:
 		return false
@@ -442,7 +584,7 @@ func (c *Client) parseTemplateAnalyticsResponse(ctx context.Context, account *Ac
 	for nextURL != "" && pageCount < maxPages {
 		c.Log.Debug("Fetching next page of template analytics", "page", pageCount+1, "url", nextURL)
 
-		pageRespBody, err := c.doRequest(ctx, http.MethodGet, nextURL, nil, account.AccessToken)
+		pageRespBody, err := c.doRequest(ctx, http.MethodGet, nextURL, nil, account)
 		if err != nil {
 			c.Log.Error("Failed to fetch template analytics page", "error", err, "page", pageCount+1)
 			break
@@ -0,0 +1,151 @@
+package whatsapp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GetConversationAnalytics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/987654321", r.URL.Path)
+		assert.Equal(t,
+			`conversation_analytics.start(1700000000).end(1702592000).granularity(DAILY).dimensions(["CONVERSATION_CATEGORY","COUNTRY"])`,
+			r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"987654321","conversation_analytics":{"data":[{"data_points":[
+			{"start":1700000000,"end":1700086400,"conversation":12,"cost":0.84,"country":"IN","conversation_category":"MARKETING"},
+			{"start":1700000000,"end":1700086400,"conversation":3,"cost":0,"country":"US","conversation_category":"SERVICE"}
+		]}]}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	analytics, err := client.GetConversationAnalytics(context.Background(), testAccount(server.URL), &whatsapp.AnalyticsRequest{
+		Start:       1700000000,
+		End:         1702592000,
+		Granularity: "DAY",
+		Dimensions:  []string{whatsapp.ConversationDimensionCategory, whatsapp.ConversationDimensionCountry},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "DAILY", analytics.Granularity)
+	require.Len(t, analytics.DataPoints, 2)
+	assert.Equal(t, int64(12), analytics.DataPoints[0].Conversation)
+	assert.InDelta(t, 0.84, analytics.DataPoints[0].Cost, 1e-9)
+	assert.Equal(t, "MARKETING", analytics.DataPoints[0].ConversationCategory)
+	assert.Equal(t, "US", analytics.DataPoints[1].Country)
+}
+
+func TestClient_GetConversationAnalytics_Validation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid request should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.GetConversationAnalytics(context.Background(), testAccount(server.URL), &whatsapp.AnalyticsRequest{Start: 200, End: 100})
+	require.Error(t, err)
+
+	_, err = client.GetConversationAnalytics(context.Background(), testAccount(server.URL), &whatsapp.AnalyticsRequest{
+		Start: 100, End: 200, Dimensions: []string{"CATEGORY"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid conversation analytics dimension")
+}
+
+func TestClient_GetTemplateAnalytics(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/987654321/template_analytics", r.URL.Path)
+		assert.Equal(t, "1700000000", r.URL.Query().Get("start"))
+		assert.Equal(t, "1700086400", r.URL.Query().Get("end"))
+		assert.Equal(t, "[111,222]", r.URL.Query().Get("template_ids"))
+
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"granularity":"DAILY","data_points":[
+				{"template_id":"111","start":1700000000,"end":1700086400,"sent":100,"delivered":95,"read":60,
+				 "clicked":[{"type":"quick_reply_button","button_content":"Yes","count":7},{"type":"unique_url_button","button_content":"Shop","count":5}]}
+			]}],"paging":{"next":"` + server.URL + r.URL.Path + "?" + r.URL.RawQuery + `&after=c1"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"granularity":"DAILY","data_points":[
+			{"template_id":"222","start":1700000000,"end":1700086400,"sent":10,"delivered":10,"read":2}
+		]}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	analytics, err := client.GetTemplateAnalytics(context.Background(), testAccount(server.URL),
+		[]string{"111", "222"}, time.Unix(1700000000, 0), time.Unix(1700086400, 0))
+	require.NoError(t, err)
+	require.Len(t, analytics.DataPoints, 2)
+
+	first := analytics.DataPoints[0]
+	assert.Equal(t, "111", first.TemplateID)
+	assert.Equal(t, int64(95), first.Delivered)
+	require.Len(t, first.Clicked, 2)
+	assert.Equal(t, "Shop", first.Clicked[1].ButtonContent)
+	assert.Equal(t, int64(12), first.Clicks())
+
+	assert.Equal(t, "222", analytics.DataPoints[1].TemplateID)
+	assert.Zero(t, analytics.DataPoints[1].Clicks())
+}
+
+func TestClient_GetTemplateAnalytics_ChunksTemplateIDs(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		ids := strings.Split(strings.Trim(r.URL.Query().Get("template_ids"), "[]"), ",")
+		assert.LessOrEqual(t, len(ids), 10)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"data_points":[{"template_id":"` + ids[0] + `","sent":1}]}]}`))
+	}))
+	defer server.Close()
+
+	ids := make([]string, 25)
+	for i := range ids {
+		ids[i] = strconv.Itoa(1000 + i)
+	}
+
+	client := newTestClient(t, server)
+	analytics, err := client.GetTemplateAnalytics(context.Background(), testAccount(server.URL),
+		ids, time.Unix(1700000000, 0), time.Unix(1700086400, 0))
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+	require.Len(t, analytics.DataPoints, 3)
+	assert.Equal(t, "1020", analytics.DataPoints[2].TemplateID)
+}
+
+func TestClient_GetTemplateAnalytics_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid request should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	now := time.Now()
+	_, err := client.GetTemplateAnalytics(context.Background(), testAccount(server.URL), nil, now, now)
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package whatsapp
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitBreakerConfig stops sending requests during a Meta outage. After
+// FailureThreshold consecutive 5xx responses or network failures (including
+// timeouts) the circuit opens and requests fail fast with ErrCircuitOpen until
+// Cooldown has elapsed; then a single trial request decides whether it closes
+// again. The zero value disables the breaker.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit; <= 0 disables the breaker
+	Cooldown         time.Duration // Time the circuit stays open before a trial request; 0 uses 30s
+}
+
+// defaultBreakerCooldown is used when CircuitBreakerConfig.Cooldown is not set
+const defaultBreakerCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned without contacting Meta while the circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitState is the state of a client's circuit breaker
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"    // Requests are sent normally
+	CircuitOpen     CircuitState = "open"      // Requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen CircuitState = "half_open" // The cooldown elapsed; the next request is a trial
+)
+
+// attemptResult is how a request attempt counts towards the circuit breaker
+type attemptResult int
+
+const (
+	attemptSucceeded attemptResult = iota // Meta responded, even with a client error
+	attemptFailed                         // 5xx response or network failure
+	attemptAborted                        // Canceled by the caller; says nothing about Meta
+)
+
+// circuitBreaker holds the breaker state of a Client
+type circuitBreaker struct {
+	mu       sync.Mutex
+	failures int // Consecutive failed attempts
+	open     bool
+	openedAt time.Time
+	trial    bool // A trial request is in flight while half-open
+}
+
+// CircuitState reports the circuit breaker state, e.g. for health checks.
+// It is always CircuitClosed when the breaker is disabled.
+func (c *Client) CircuitState() CircuitState {
+	if c.Breaker.FailureThreshold <= 0 {
+		return CircuitClosed
+	}
+
+	b := &c.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch {
+	case !b.open:
+		return CircuitClosed
+	case b.trial || time.Since(b.openedAt) >= c.breakerCooldown():
+		return CircuitHalfOpen
+	default:
+		return CircuitOpen
+	}
+}
+
+// breakerCooldown returns the configured cooldown or its default
+func (c *Client) breakerCooldown() time.Duration {
+	if c.Breaker.Cooldown > 0 {
+		return c.Breaker.Cooldown
+	}
+	return defaultBreakerCooldown
+}
+
+// allowRequest fails with ErrCircuitOpen while the circuit is open. Once the
+// cooldown has elapsed it lets a single trial request through.
+func (c *Client) allowRequest() error {
+	if c.Breaker.FailureThreshold <= 0 {
+		return nil
+	}
+
+	b := &c.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < c.breakerCooldown() {
+		return fmt.Errorf("%w after %d consecutive failures", ErrCircuitOpen, b.failures)
+	}
+	b.trial = true
+	return nil
+}
+
+// recordAttempt updates the circuit breaker with the result of a request
+// attempt that allowRequest let through
+func (c *Client) recordAttempt(result attemptResult) {
+	if c.Breaker.FailureThreshold <= 0 {
+		return
+	}
+
+	b := &c.breaker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch result {
+	case attemptSucceeded:
+		if b.open {
+			c.Log.Info("Circuit breaker closed", "failures", b.failures)
+		}
+		b.failures = 0
+		b.open = false
+		b.trial = false
+	case attemptFailed:
+		b.failures++
+		if b.trial || (!b.open && b.failures >= c.Breaker.FailureThreshold) {
+			if !b.open {
+				c.Log.Warn("Circuit breaker opened", "failures", b.failures, "cooldown", c.breakerCooldown())
+			}
+			b.open = true
+			b.openedAt = time.Now()
+			b.trial = false
+		}
+	case attemptAborted:
+		b.trial = false
+	}
+}
@@ -0,0 +1,99 @@
+package whatsapp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBreakerTestServer responds with the status held in status and counts its calls
+func newBreakerTestServer(t *testing.T, status *atomic.Int32, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	t.Parallel()
+
+	var status, calls atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	server := newBreakerTestServer(t, &status, &calls)
+	client := newTestClient(t, server)
+	client.Breaker = whatsapp.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: 50 * time.Millisecond}
+	ctx := context.Background()
+	account := testAccount(server.URL)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.ListCatalogs(ctx, account)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, whatsapp.ErrCircuitOpen)
+	}
+	assert.Equal(t, whatsapp.CircuitOpen, client.CircuitState())
+
+	// Requests fail fast while the circuit is open
+	_, err := client.ListCatalogs(ctx, account)
+	require.ErrorIs(t, err, whatsapp.ErrCircuitOpen)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// A failed trial request reopens the circuit
+	time.Sleep(60 * time.Millisecond)
+	assert.Equal(t, whatsapp.CircuitHalfOpen, client.CircuitState())
+	_, err = client.ListCatalogs(ctx, account)
+	require.Error(t, err)
+	assert.Equal(t, whatsapp.CircuitOpen, client.CircuitState())
+	assert.Equal(t, int32(3), calls.Load())
+
+	// A successful trial request closes it
+	time.Sleep(60 * time.Millisecond)
+	status.Store(http.StatusOK)
+	_, err = client.ListCatalogs(ctx, account)
+	require.NoError(t, err)
+	assert.Equal(t, whatsapp.CircuitClosed, client.CircuitState())
+}
+
+func TestClient_CircuitBreaker_IgnoresClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var status, calls atomic.Int32
+	status.Store(http.StatusBadRequest)
+	server := newBreakerTestServer(t, &status, &calls)
+	client := newTestClient(t, server)
+	client.Breaker = whatsapp.CircuitBreakerConfig{FailureThreshold: 1}
+
+	for i := 0; i < 3; i++ {
+		_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, whatsapp.ErrCircuitOpen)
+	}
+	assert.Equal(t, whatsapp.CircuitClosed, client.CircuitState())
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_CircuitBreaker_Disabled(t *testing.T) {
+	t.Parallel()
+
+	var status, calls atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	server := newBreakerTestServer(t, &status, &calls)
+	client := newTestClient(t, server)
+
+	for i := 0; i < 5; i++ {
+		_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+		require.Error(t, err)
+	}
+	assert.Equal(t, whatsapp.CircuitClosed, client.CircuitState())
+	assert.Equal(t, int32(5), calls.Load())
+}
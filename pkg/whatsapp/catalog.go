@@ -3,10 +3,17 @@ package whatsapp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // buildCatalogsURL builds the catalogs endpoint URL for a business
@@ -19,6 +26,11 @@ func (c *Client) buildCatalogProductsURL(account *Account, catalogID string) str
 	return fmt.Sprintf("%s/%s/%s/products", c.getBaseURL(), account.APIVersion, catalogID)
 }
 
+// buildProductSetsURL builds the product sets endpoint URL for a catalog
+func (c *Client) buildProductSetsURL(account *Account, catalogID string) string {
+	return fmt.Sprintf("%s/%s/%s/product_sets", c.getBaseURL(), account.APIVersion, catalogID)
+}
+
 // buildProductURL builds the URL for a specific product
 func (c *Client) buildProductURL(account *Account, productID string) string {
 	return fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, productID)
@@ -32,7 +44,7 @@ func (c *Client) CreateCatalog(ctx context.Context, account *Account, name strin
 		"name": name,
 	}
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, apiURL, body, account.AccessToken)
+	respBody, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
 	if err != nil {
 		return "", err
 	}
@@ -47,41 +59,313 @@ func (c *Client) CreateCatalog(ctx context.Context, account *Account, name strin
 	return resp.ID, nil
 }
 
-// ListCatalogs lists all catalogs for a business
-func (c *Client) ListCatalogs(ctx context.Context, account *Account) ([]CatalogInfo, error) {
-	apiURL := c.buildCatalogsURL(account)
+// ErrCatalogNotOwned is returned when connecting a catalog that the business
+// neither owns nor has been granted access to
+var ErrCatalogNotOwned = errors.New("catalog not owned by business")
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account.AccessToken)
+// Graph API codes returned when the business lacks access to a catalog
+const (
+	errCodePermissionDenied = 10
+	errCodePermissionsMin   = 200 // Permission errors are reported as codes 200-299
+	errCodePermissionsMax   = 299
+)
+
+// buildWABACatalogsURL builds the URL for the catalogs connected to the WABA
+func (c *Client) buildWABACatalogsURL(account *Account) string {
+	return fmt.Sprintf("%s/%s/%s/product_catalogs", c.getBaseURL(), account.APIVersion, account.BusinessID)
+}
+
+// ConnectCatalogToWABA connects a catalog to the WhatsApp Business Account's
+// commerce settings, which is required before sending product messages
+func (c *Client) ConnectCatalogToWABA(ctx context.Context, account *Account, catalogID string) error {
+	if catalogID == "" {
+		return fmt.Errorf("catalog ID is required")
+	}
+
+	body := map[string]string{
+		"catalog_id": catalogID,
+	}
+
+	_, _, err := c.doCatalogWrite(ctx, http.MethodPost, c.buildWABACatalogsURL(account), body, account)
 	if err != nil {
-		return nil, err
+		var apiErr *GraphAPIError
+		if errors.As(err, &apiErr) && (apiErr.Code == errCodePermissionDenied ||
+			(apiErr.Code >= errCodePermissionsMin && apiErr.Code <= errCodePermissionsMax)) {
+			return fmt.Errorf("%w: %s: %w", ErrCatalogNotOwned, catalogID, err)
+		}
+		return fmt.Errorf("failed to connect catalog: %w", err)
+	}
+
+	c.Log.Info("Catalog connected to WABA", "catalog_id", catalogID, "business_id", account.BusinessID)
+	return nil
+}
+
+// GetConnectedCatalog returns the ID of the catalog connected to the WhatsApp
+// Business Account, or an empty string if none is connected
+func (c *Client) GetConnectedCatalog(ctx context.Context, account *Account) (string, error) {
+	respBody, err := c.doRequest(ctx, http.MethodGet, c.buildWABACatalogsURL(account), nil, account)
+	if err != nil {
+		return "", fmt.Errorf("failed to get connected catalog: %w", err)
 	}
 
 	var resp CatalogListResponse
 	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// A WABA has at most one connected catalog
+	if len(resp.Data) == 0 {
+		return "", nil
+	}
+	return resp.Data[0].ID, nil
+}
+
+const (
+	// catalogFields are the catalog fields requested when listing catalogs
+	catalogFields = "id,name,product_count,vertical"
+	// catalogListPageLimit is the page size used when listing all catalogs
+	catalogListPageLimit = 100
+)
+
+// ListCatalogs lists all catalogs for a business, following pagination
+// until the last page. fields selects the catalog fields to fetch; none
+// fetches the default set.
+func (c *Client) ListCatalogs(ctx context.Context, account *Account, fields ...string) ([]CatalogInfo, error) {
+	return collectPages(func(cursor string) (*Page[CatalogInfo], error) {
+		return c.ListCatalogsPaginated(ctx, account, cursor, catalogListPageLimit, fields...)
+	})
+}
+
+// ListCatalogsPaginated lists a single page of catalogs for a business.
+// Pass an empty cursor for the first page and the page's NextCursor for the
+// following ones. A limit <= 0 uses Meta's default page size, and fields
+// works as for ListCatalogs.
+func (c *Client) ListCatalogsPaginated(ctx context.Context, account *Account, cursor string, limit int, fields ...string) (*Page[CatalogInfo], error) {
+	params := url.Values{}
+	params.Add("fields", selectFields(catalogFields, fields))
+	params.Add("summary", "total_count")
+	if cursor != "" {
+		params.Add("after", cursor)
+	}
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
+	}
+	apiURL := c.buildCatalogsURL(account) + "?" + params.Encode()
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePage[CatalogInfo](respBody)
+}
+
+// ErrCatalogNotFound is returned when a catalog ID matches no catalog.
+// It also matches ErrNotFound.
+var ErrCatalogNotFound = fmt.Errorf("catalog %w", ErrNotFound)
+
+// buildCatalogURL builds the URL for a specific catalog
+func (c *Client) buildCatalogURL(account *Account, catalogID string) string {
+	return fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, catalogID)
+}
+
+// GetCatalog fetches a single catalog by ID, including its product count
+// unless fields selects other catalog fields; "id" is always fetched
+func (c *Client) GetCatalog(ctx context.Context, account *Account, catalogID string, fields ...string) (*CatalogInfo, error) {
+	apiURL := c.buildCatalogURL(account, catalogID) + "?fields=" + url.QueryEscape(selectFields(catalogFields, withField(fields, "id")))
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrCatalogNotFound, catalogID)
+		}
+		return nil, err
+	}
+
+	var catalog CatalogInfo
+	if err := json.Unmarshal(respBody, &catalog); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return resp.Data, nil
+	if catalog.ID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrCatalogNotFound, catalogID)
+	}
+
+	return &catalog, nil
+}
+
+// UpdateCatalog renames a catalog, keeping its products and their IDs
+func (c *Client) UpdateCatalog(ctx context.Context, account *Account, catalogID, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("catalog name is required")
+	}
+
+	body := map[string]string{
+		"name": name,
+	}
+
+	_, _, err := c.doCatalogWrite(ctx, http.MethodPost, c.buildCatalogURL(account, catalogID), body, account)
+	return err
 }
 
 // DeleteCatalog deletes a catalog
 func (c *Client) DeleteCatalog(ctx context.Context, account *Account, catalogID string) error {
-	apiURL := fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, catalogID)
+	apiURL := c.buildCatalogURL(account, catalogID)
 
-	_, err := c.doRequest(ctx, http.MethodDelete, apiURL, nil, account.AccessToken)
+	_, _, err := c.doCatalogWrite(ctx, http.MethodDelete, apiURL, nil, account)
 	return err
 }
 
-// ListCatalogProducts lists all products in a catalog
-func (c *Client) ListCatalogProducts(ctx context.Context, account *Account, catalogID string) ([]ProductInfo, error) {
+const (
+	// productFields is the set of product fields requested from Meta
+	productFields = "id,name,price,currency,url,image_url,retailer_id,description,availability,condition,retailer_product_group_id,additional_image_urls"
+	// productListPageLimit is the page size used when listing all products
+	productListPageLimit = 100
+)
+
+// selectFields returns the fields parameter for a read: the requested fields
+// joined with commas, or defaults if none were requested
+func selectFields(defaults string, fields []string) string {
+	if len(fields) == 0 {
+		return defaults
+	}
+	return strings.Join(fields, ",")
+}
+
+// withField adds field to a non-empty field selection that lacks it, for
+// reads whose result checks depend on that field
+func withField(fields []string, field string) []string {
+	if len(fields) == 0 || slices.Contains(fields, field) {
+		return fields
+	}
+	return append(slices.Clip(fields), field)
+}
+
+// ListCatalogProducts lists all products in a catalog, following pagination
+// until the last page. fields selects the product fields to fetch, e.g.
+// "id", "retailer_id", "price" for a lightweight availability check; none
+// fetches the default set. Fields that are not fetched are left empty.
+func (c *Client) ListCatalogProducts(ctx context.Context, account *Account, catalogID string, fields ...string) ([]ProductInfo, error) {
+	return collectPages(func(cursor string) (*Page[ProductInfo], error) {
+		return c.ListCatalogProductsPaginated(ctx, account, catalogID, cursor, productListPageLimit, fields...)
+	})
+}
+
+// IterateProducts returns an iterator over all products in a catalog that
+// fetches pages lazily as the caller ranges over it, so large catalogs are
+// never held in memory:
+//
+//	for product, err := range client.IterateProducts(ctx, account, catalogID) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// An error, including ctx being canceled, is yielded once and ends the
+// iteration. fields works as for ListCatalogProducts.
+func (c *Client) IterateProducts(ctx context.Context, account *Account, catalogID string, fields ...string) iter.Seq2[ProductInfo, error] {
+	return func(yield func(ProductInfo, error) bool) {
+		err := walkPages(func(cursor string) (*Page[ProductInfo], error) {
+			return c.ListCatalogProductsPaginated(ctx, account, catalogID, cursor, productListPageLimit, fields...)
+		}, func(page *Page[ProductInfo]) bool {
+			for _, product := range page.Data {
+				if err := ctx.Err(); err != nil {
+					yield(ProductInfo{}, err)
+					return false
+				}
+				if !yield(product, nil) {
+					return false
+				}
+			}
+			return true
+		})
+		if err != nil {
+			yield(ProductInfo{}, err)
+		}
+	}
+}
+
+// ListCatalogProductsPaginated lists a single page of products in a catalog.
+// Pass an empty cursor for the first page and the page's NextCursor for the
+// following ones. A limit <= 0 uses Meta's default page size, and fields
+// works as for ListCatalogProducts.
+func (c *Client) ListCatalogProductsPaginated(ctx context.Context, account *Account, catalogID, cursor string, limit int, fields ...string) (*Page[ProductInfo], error) {
 	apiURL := c.buildCatalogProductsURL(account, catalogID)
 
-	// Add fields parameter to get all product details
+	// Add fields parameter to get the product details
 	params := url.Values{}
-	params.Add("fields", "id,name,price,currency,url,image_url,retailer_id,description")
+	params.Add("fields", selectFields(productFields, fields))
+	params.Add("summary", "total_count")
+	if cursor != "" {
+		params.Add("after", cursor)
+	}
+	if limit > 0 {
+		params.Add("limit", strconv.Itoa(limit))
+	}
 	apiURL = apiURL + "?" + params.Encode()
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePage[ProductInfo](respBody)
+}
+
+// ErrProductNotFound is returned when a product lookup matches no product.
+// It also matches ErrNotFound.
+var ErrProductNotFound = fmt.Errorf("product %w", ErrNotFound)
+
+// GetProduct fetches a single product by its Meta product ID. fields works
+// as for ListCatalogProducts; "id" is always fetched.
+func (c *Client) GetProduct(ctx context.Context, account *Account, productID string, fields ...string) (*ProductInfo, error) {
+	params := url.Values{}
+	params.Add("fields", selectFields(productFields, withField(fields, "id")))
+	apiURL := c.buildProductURL(account, productID) + "?" + params.Encode()
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+		}
+		return nil, err
+	}
+
+	var product ProductInfo
+	if err := json.Unmarshal(respBody, &product); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if product.ID == "" {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, productID)
+	}
+
+	return &product, nil
+}
+
+// GetProductByRetailerID fetches a single product by its retailer ID (SKU)
+// without listing the whole catalog. fields works as for ListCatalogProducts;
+// "retailer_id" is always fetched.
+func (c *Client) GetProductByRetailerID(ctx context.Context, account *Account, catalogID, retailerID string, fields ...string) (*ProductInfo, error) {
+	if retailerID == "" {
+		return nil, fmt.Errorf("retailer ID is required")
+	}
+
+	filter, err := json.Marshal(map[string]interface{}{
+		"retailer_id": map[string]string{"eq": retailerID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal filter: %w", err)
+	}
+
+	params := url.Values{}
+	params.Add("fields", selectFields(productFields, withField(fields, "retailer_id")))
+	params.Add("filter", string(filter))
+	params.Add("limit", "1")
+	apiURL := c.buildCatalogProductsURL(account, catalogID) + "?" + params.Encode()
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
 	if err != nil {
 		return nil, err
 	}
@@ -91,30 +375,51 @@ func (c *Client) ListCatalogProducts(ctx context.Context, account *Account, cata
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return resp.Data, nil
+	for _, product := range resp.Data {
+		if product.RetailerID == retailerID {
+			return &product, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: retailer ID %s", ErrProductNotFound, retailerID)
 }
 
-// CreateProduct adds a product to a catalog
-func (c *Client) CreateProduct(ctx context.Context, account *Account, catalogID string, product *ProductInput) (string, error) {
-	apiURL := c.buildCatalogProductsURL(account, catalogID)
+// catalogImageTypes are the image formats accepted for catalog products
+var catalogImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
 
-	// Meta API expects price as string with currency code
-	priceStr := strconv.FormatInt(product.Price, 10)
+// UploadCatalogImage uploads a product image through the Resumable Upload API
+// and returns a handle for ProductInput.ImageHandle, so product images do not
+// have to be publicly hosted. Like ResumableUpload it requires account.AppID.
+func (c *Client) UploadCatalogImage(ctx context.Context, account *Account, catalogID string, r io.Reader, mimeType string) (string, error) {
+	if !catalogImageTypes[mimeType] {
+		return "", fmt.Errorf("unsupported catalog image type %q", mimeType)
+	}
 
-	body := map[string]string{
-		"name":        product.Name,
-		"price":       priceStr,
-		"currency":    product.Currency,
-		"url":         product.URL,
-		"image_url":   product.ImageURL,
-		"retailer_id": product.RetailerID,
+	// The upload session needs the file length up front
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to read catalog image: %w", err)
+	}
+	if len(data) == 0 {
+		return "", fmt.Errorf("catalog image is empty")
 	}
 
-	if product.Description != "" {
-		body["description"] = product.Description
+	return c.ResumableUpload(ctx, account, data, mimeType, "catalog_"+catalogID)
+}
+
+// CreateProduct adds a product to a catalog
+func (c *Client) CreateProduct(ctx context.Context, account *Account, catalogID string, product *ProductInput) (string, error) {
+	apiURL := c.buildCatalogProductsURL(account, catalogID)
+
+	body, err := buildProductBody(product, false)
+	if err != nil {
+		return "", err
 	}
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, apiURL, body, account.AccessToken)
+	respBody, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
 	if err != nil {
 		return "", err
 	}
@@ -127,39 +432,715 @@ func (c *Client) CreateProduct(ctx context.Context, account *Account, catalogID
 	return resp.ID, nil
 }
 
+// UpsertProduct creates the product if its RetailerID is new to the catalog and
+// updates the existing product otherwise, returning the product ID. This makes
+// retried syncs safe at the cost of an extra read (GetProductByRetailerID)
+// per call; use BatchUpsertProducts for bulk imports.
+func (c *Client) UpsertProduct(ctx context.Context, account *Account, catalogID string, product *ProductInput) (string, error) {
+	id, _, err := c.upsertProduct(ctx, account, catalogID, product)
+	return id, err
+}
+
+// upsertProduct implements UpsertProduct and also reports whether the product
+// was created rather than updated
+func (c *Client) upsertProduct(ctx context.Context, account *Account, catalogID string, product *ProductInput) (string, bool, error) {
+	if product.RetailerID == "" {
+		return "", false, fmt.Errorf("retailer ID is required for upsert")
+	}
+
+	existing, err := c.GetProductByRetailerID(ctx, account, catalogID, product.RetailerID)
+	if errors.Is(err, ErrProductNotFound) {
+		id, err := c.CreateProduct(ctx, account, catalogID, product)
+		return id, err == nil, err
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up product: %w", err)
+	}
+
+	if err := c.UpdateProduct(ctx, account, existing.ID, product); err != nil {
+		return "", false, err
+	}
+	return existing.ID, false, nil
+}
+
 // UpdateProduct updates a product
 func (c *Client) UpdateProduct(ctx context.Context, account *Account, productID string, product *ProductInput) error {
 	apiURL := c.buildProductURL(account, productID)
 
-	body := make(map[string]string)
+	body, err := buildProductBody(product, true)
+	if err != nil {
+		return err
+	}
 
-	if product.Name != "" {
-		body["name"] = product.Name
+	_, _, err = c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+	return err
+}
+
+// SetProductAvailability updates only the availability of a product, e.g. to
+// mark it out of stock without resending the rest of its fields
+func (c *Client) SetProductAvailability(ctx context.Context, account *Account, productID string, availability Availability) error {
+	if err := validateAvailability(availability); err != nil {
+		return err
 	}
-	if product.Price > 0 {
-		body["price"] = strconv.FormatInt(product.Price, 10)
+
+	apiURL := c.buildProductURL(account, productID)
+	body := map[string]interface{}{
+		"availability": availability,
 	}
-	if product.Currency != "" {
-		body["currency"] = product.Currency
+
+	_, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+	return err
+}
+
+// productFieldClearable lists the product fields UpdateProductFields accepts.
+// Fields mapped to true are optional on Meta's side and may be cleared with an
+// empty string; the others are required for a product and can only be changed.
+var productFieldClearable = map[string]bool{
+	"name":         false,
+	"price":        false,
+	"currency":     false,
+	"image_url":    false,
+	"availability": false,
+	"condition":    false,
+	"description":  true,
+	"url":          true,
+	"brand":        true,
+	"sale_price":   true,
+	// Sale dates use Meta's ISO 8601 range format, e.g. "2024-01-01T00:00+00:00/2024-02-01T00:00+00:00"
+	"sale_price_effective_date": true,
+}
+
+// UpdateProductFields updates individual product fields by their Meta API
+// names. A nil value leaves the field unchanged and an empty string clears it,
+// which UpdateProduct cannot express. Only the optional fields in
+// productFieldClearable (description, url, brand, sale_price and
+// sale_price_effective_date) may be cleared; price and sale_price are sent as
+// the decimal strings Meta expects (see FormatPrice).
+func (c *Client) UpdateProductFields(ctx context.Context, account *Account, productID string, fields map[string]*string) error {
+	body := make(map[string]interface{})
+	for name, value := range fields {
+		clearable, ok := productFieldClearable[name]
+		if !ok {
+			return fmt.Errorf("unsupported product field %q", name)
+		}
+		if value == nil {
+			continue
+		}
+		if *value == "" && !clearable {
+			return fmt.Errorf("product field %q is required and cannot be cleared", name)
+		}
+
+		switch name {
+		case "availability":
+			if err := validateAvailability(Availability(*value)); err != nil {
+				return err
+			}
+		case "condition":
+			if !validProductConditions[*value] {
+				return fmt.Errorf("invalid product condition %q", *value)
+			}
+		case "currency":
+			if err := validateCurrency(Currency(*value)); err != nil {
+				return err
+			}
+		}
+		body[name] = *value
 	}
-	if product.URL != "" {
-		body["url"] = product.URL
+
+	if len(body) == 0 {
+		return fmt.Errorf("no product fields to update")
 	}
-	if product.ImageURL != "" {
-		body["image_url"] = product.ImageURL
+
+	apiURL := c.buildProductURL(account, productID)
+	_, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+	return err
+}
+
+// Availability is a product's stock status as the Commerce API names it.
+// Values from external data can be converted with ParseAvailability.
+type Availability string
+
+// Product availability values accepted by the Commerce API
+const (
+	ProductAvailabilityInStock          Availability = "in stock"
+	ProductAvailabilityOutOfStock       Availability = "out of stock"
+	ProductAvailabilityPreorder         Availability = "preorder"
+	ProductAvailabilityAvailableToOrder Availability = "available for order"
+	ProductAvailabilityDiscontinued     Availability = "discontinued"
+)
+
+// ParseAvailability converts an availability from external data into an
+// Availability. Case, surrounding spaces and the underscore spelling used
+// by some feeds (e.g. "IN_STOCK") are accepted.
+func ParseAvailability(s string) (Availability, error) {
+	availability := Availability(strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", " ")))
+	if !availability.Valid() {
+		return "", fmt.Errorf("invalid product availability %q", s)
 	}
+	return availability, nil
+}
+
+// Valid reports whether a is one of the ProductAvailability* values
+func (a Availability) Valid() bool {
+	return validProductAvailabilities[a]
+}
+
+// Product condition values accepted by the Commerce API
+const (
+	ProductConditionNew         = "new"
+	ProductConditionRefurbished = "refurbished"
+	ProductConditionUsed        = "used"
+)
+
+var validProductAvailabilities = map[Availability]bool{
+	ProductAvailabilityInStock:          true,
+	ProductAvailabilityOutOfStock:       true,
+	ProductAvailabilityPreorder:         true,
+	ProductAvailabilityAvailableToOrder: true,
+	ProductAvailabilityDiscontinued:     true,
+}
+
+var validProductConditions = map[string]bool{
+	ProductConditionNew:         true,
+	ProductConditionRefurbished: true,
+	ProductConditionUsed:        true,
+}
+
+// validateAvailability checks that availability is a value the Commerce API accepts
+func validateAvailability(availability Availability) error {
+	if !availability.Valid() {
+		return fmt.Errorf("invalid product availability %q", availability)
+	}
+	return nil
+}
+
+// maxRetailerIDLength is the maximum length of a product's retailer ID (SKU)
+const maxRetailerIDLength = 100
+
+// validateRetailerID checks that a retailer ID is set, fits Meta's length limit
+// and contains no whitespace or control characters, which Meta rejects or
+// silently strips so that distinct SKUs collide
+func validateRetailerID(retailerID string) error {
+	if retailerID == "" {
+		return fmt.Errorf("retailer ID is required")
+	}
+	if utf8.RuneCountInString(retailerID) > maxRetailerIDLength {
+		return fmt.Errorf("invalid retailer ID %q: exceeds %d characters", retailerID, maxRetailerIDLength)
+	}
+	if i := strings.IndexFunc(retailerID, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }); i >= 0 {
+		return fmt.Errorf("invalid retailer ID %q: must not contain whitespace or control characters", retailerID)
+	}
+	return nil
+}
+
+// salePriceDateLayout is the ISO 8601 layout of each end of sale_price_effective_date
+const salePriceDateLayout = "2006-01-02T15:04-07:00"
+
+// validateSalePrice checks that a sale is cheaper than the regular price and
+// that its period, if any, is complete and ordered
+func validateSalePrice(product *ProductInput) error {
+	hasPeriod := !product.SalePriceStart.IsZero() || !product.SalePriceEnd.IsZero()
+	if product.SalePrice <= 0 {
+		if product.SalePrice < 0 {
+			return fmt.Errorf("sale price must not be negative")
+		}
+		if hasPeriod {
+			return fmt.Errorf("sale price period requires a sale price")
+		}
+		return nil
+	}
+
+	// Updates may omit the regular price, in which case Meta enforces the rule
+	if product.Price > 0 && product.SalePrice >= product.Price {
+		return fmt.Errorf("sale price %d must be lower than price %d", product.SalePrice, product.Price)
+	}
+	if hasPeriod {
+		if product.SalePriceStart.IsZero() || product.SalePriceEnd.IsZero() {
+			return fmt.Errorf("sale price period requires both start and end")
+		}
+		if !product.SalePriceStart.Before(product.SalePriceEnd) {
+			return fmt.Errorf("sale price start must be before end")
+		}
+	}
+	return nil
+}
+
+// maxAdditionalImages is the maximum number of additional images per product
+const maxAdditionalImages = 20
+
+// buildProductBody builds the request body for creating or updating a product.
+// Creates always send the core fields; updates only send fields that are set
+// so that unspecified values are left unchanged on Meta's side.
+func buildProductBody(product *ProductInput, isUpdate bool) (map[string]interface{}, error) {
+	if product.Availability != "" {
+		if err := validateAvailability(product.Availability); err != nil {
+			return nil, err
+		}
+	}
+	if product.Condition != "" && !validProductConditions[product.Condition] {
+		return nil, fmt.Errorf("invalid product condition %q", product.Condition)
+	}
+	// The retailer ID identifies the product, so it is only sent on creates
+	if !isUpdate {
+		if err := validateRetailerID(product.RetailerID); err != nil {
+			return nil, err
+		}
+	}
+	// Creates always send a currency, so it is required there
+	if product.Currency != "" || !isUpdate {
+		if err := validateCurrency(product.Currency); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateSalePrice(product); err != nil {
+		return nil, err
+	}
+	if len(product.AdditionalImageURLs) > maxAdditionalImages {
+		return nil, fmt.Errorf("at most %d additional images are allowed, got %d", maxAdditionalImages, len(product.AdditionalImageURLs))
+	}
+	for i, imageURL := range product.AdditionalImageURLs {
+		if imageURL == "" {
+			return nil, fmt.Errorf("additional image %d: URL is required", i+1)
+		}
+	}
+
+	body := make(map[string]interface{})
+
+	if isUpdate {
+		if product.Name != "" {
+			body["name"] = product.Name
+		}
+		if product.Price > 0 {
+			// Without a currency the price is scaled as a 2-decimal currency
+			body["price"] = FormatPrice(product.Price, string(product.Currency))
+		}
+		if product.Currency != "" {
+			body["currency"] = product.Currency
+		}
+		if product.URL != "" {
+			body["url"] = product.URL
+		}
+		if product.ImageURL != "" {
+			body["image_url"] = product.ImageURL
+		} else if product.ImageHandle != "" {
+			body["image_handle"] = product.ImageHandle
+		}
+	} else {
+		// Meta API expects price as a decimal string alongside the currency code
+		body["name"] = product.Name
+		body["price"] = FormatPrice(product.Price, string(product.Currency))
+		body["currency"] = product.Currency
+		body["url"] = product.URL
+		if product.ImageURL == "" && product.ImageHandle != "" {
+			body["image_handle"] = product.ImageHandle
+		} else {
+			body["image_url"] = product.ImageURL
+		}
+		body["retailer_id"] = product.RetailerID
+	}
+
 	if product.Description != "" {
 		body["description"] = product.Description
 	}
+	if product.RetailerProductGroupID != "" {
+		body["retailer_product_group_id"] = product.RetailerProductGroupID
+	}
+	if len(product.AdditionalImageURLs) > 0 {
+		body["additional_image_urls"] = product.AdditionalImageURLs
+	}
 
-	_, err := c.doRequest(ctx, http.MethodPost, apiURL, body, account.AccessToken)
-	return err
+	if product.Availability != "" {
+		body["availability"] = product.Availability
+	}
+	if product.Condition != "" {
+		body["condition"] = product.Condition
+	}
+	for i, label := range product.CustomLabels {
+		if label != "" {
+			body[fmt.Sprintf("custom_label_%d", i)] = label
+		}
+	}
+	if len(product.CustomData) > 0 {
+		body["custom_data"] = product.CustomData
+	}
+	if product.SalePrice > 0 {
+		body["sale_price"] = FormatPrice(product.SalePrice, string(product.Currency))
+		if !product.SalePriceStart.IsZero() {
+			body["sale_price_effective_date"] = product.SalePriceStart.Format(salePriceDateLayout) +
+				"/" + product.SalePriceEnd.Format(salePriceDateLayout)
+		}
+	}
+
+	if len(product.Variants) > 0 {
+		// Meta expects variant attributes as a JSON-encoded array string
+		variantsJSON, err := json.Marshal(product.Variants)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal variant attributes: %w", err)
+		}
+		body["additional_variant_attributes"] = string(variantsJSON)
+	}
+
+	return body, nil
 }
 
 // DeleteProduct deletes a product
 func (c *Client) DeleteProduct(ctx context.Context, account *Account, productID string) error {
 	apiURL := c.buildProductURL(account, productID)
 
-	_, err := c.doRequest(ctx, http.MethodDelete, apiURL, nil, account.AccessToken)
+	_, _, err := c.doCatalogWrite(ctx, http.MethodDelete, apiURL, nil, account)
 	return err
 }
+
+// maxBatchRequests is the maximum number of item requests Meta accepts per batch call
+const maxBatchRequests = 5000
+
+// batchRequest represents a single item operation in a catalog batch call
+type batchRequest struct {
+	Method     string                 `json:"method"`
+	RetailerID string                 `json:"retailer_id"`
+	Data       map[string]interface{} `json:"data,omitempty"`
+}
+
+// batchResponse represents the response from a catalog batch call
+type batchResponse struct {
+	Handles          []string `json:"handles"`
+	ValidationStatus []struct {
+		RetailerID string `json:"retailer_id"`
+		Errors     []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	} `json:"validation_status"`
+}
+
+// BatchUpsertProducts creates or updates products in a catalog using the batch API.
+// Products are keyed by RetailerID and sent in groups of up to maxBatchRequests.
+// Meta processes batches asynchronously, so each result carries the batch handle
+// that can be used to check the processing status rather than a product ID.
+func (c *Client) BatchUpsertProducts(ctx context.Context, account *Account, catalogID string, products []ProductInput) ([]BatchResult, error) {
+	if err := checkDuplicateRetailerIDs(len(products), func(i int) string { return products[i].RetailerID }); err != nil {
+		return nil, err
+	}
+
+	requests := make([]batchRequest, 0, len(products))
+	for i := range products {
+		data, err := buildProductBody(&products[i], false)
+		if err != nil {
+			return nil, fmt.Errorf("product %s: %w", products[i].RetailerID, err)
+		}
+		// retailer_id is sent at the request level, not inside data
+		delete(data, "retailer_id")
+		requests = append(requests, batchRequest{
+			Method:     "UPDATE",
+			RetailerID: products[i].RetailerID,
+			Data:       data,
+		})
+	}
+
+	return c.sendProductBatch(ctx, account, catalogID, requests)
+}
+
+// BatchDeleteProducts removes products from a catalog by retailer ID (SKU)
+// using the batch API, so the Meta product IDs are not needed. Each result
+// carries the batch handle or the error reported for that item.
+func (c *Client) BatchDeleteProducts(ctx context.Context, account *Account, catalogID string, retailerIDs []string) ([]BatchResult, error) {
+	if err := checkDuplicateRetailerIDs(len(retailerIDs), func(i int) string { return retailerIDs[i] }); err != nil {
+		return nil, err
+	}
+
+	requests := make([]batchRequest, 0, len(retailerIDs))
+	for _, retailerID := range retailerIDs {
+		if retailerID == "" {
+			return nil, fmt.Errorf("retailer ID is required")
+		}
+		requests = append(requests, batchRequest{
+			Method:     "DELETE",
+			RetailerID: retailerID,
+		})
+	}
+
+	return c.sendProductBatch(ctx, account, catalogID, requests)
+}
+
+// checkDuplicateRetailerIDs fails if a retailer ID occurs more than once among
+// the n items of a batch, as Meta would apply only one of the conflicting requests
+func checkDuplicateRetailerIDs(n int, retailerID func(i int) string) error {
+	seen := make(map[string]int, n)
+	for i := 0; i < n; i++ {
+		id := retailerID(i)
+		if first, ok := seen[id]; ok {
+			return fmt.Errorf("duplicate retailer ID %q in batch at items %d and %d", id, first+1, i+1)
+		}
+		seen[id] = i
+	}
+	return nil
+}
+
+// sendProductBatch sends item requests to the catalog batch endpoint in chunks
+// and returns one result per request, in the same order as the input.
+// A failed chunk marks every item in it as failed without aborting later chunks.
+func (c *Client) sendProductBatch(ctx context.Context, account *Account, catalogID string, requests []batchRequest) ([]BatchResult, error) {
+	apiURL := fmt.Sprintf("%s/%s/%s/batch", c.getBaseURL(), account.APIVersion, catalogID)
+	results := make([]BatchResult, 0, len(requests))
+
+	for start := 0; start < len(requests); start += maxBatchRequests {
+		if err := ctx.Err(); err != nil {
+			return results, err
+		}
+
+		end := start + maxBatchRequests
+		if end > len(requests) {
+			end = len(requests)
+		}
+		chunk := requests[start:end]
+
+		body := map[string]interface{}{
+			"allow_upsert": true,
+			"requests":     chunk,
+		}
+
+		respBody, meta, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+		traceID := ""
+		if err == nil {
+			traceID = meta.FBTraceID
+			var resp batchResponse
+			if jsonErr := json.Unmarshal(respBody, &resp); jsonErr != nil {
+				err = fmt.Errorf("failed to parse response: %w", jsonErr)
+			} else {
+				results = append(results, buildBatchResults(chunk, &resp, traceID)...)
+				continue
+			}
+		} else {
+			var apiErr *GraphAPIError
+			if errors.As(err, &apiErr) {
+				traceID = apiErr.FBTraceID
+			}
+		}
+
+		c.Log.Error("Catalog batch request failed", "error", err, "catalog_id", catalogID, "items", len(chunk), "fbtrace_id", traceID)
+		for _, req := range chunk {
+			results = append(results, BatchResult{RetailerID: req.RetailerID, FBTraceID: traceID, Err: err})
+		}
+	}
+
+	return results, nil
+}
+
+// buildBatchResults maps a batch response back onto the requests that produced it
+func buildBatchResults(requests []batchRequest, resp *batchResponse, traceID string) []BatchResult {
+	handle := ""
+	if len(resp.Handles) > 0 {
+		handle = resp.Handles[0]
+	}
+
+	itemErrors := make(map[string]error)
+	for _, status := range resp.ValidationStatus {
+		if len(status.Errors) == 0 {
+			continue
+		}
+		messages := make([]string, 0, len(status.Errors))
+		for _, e := range status.Errors {
+			messages = append(messages, e.Message)
+		}
+		itemErrors[status.RetailerID] = fmt.Errorf("validation failed: %s", strings.Join(messages, "; "))
+	}
+
+	results := make([]BatchResult, 0, len(requests))
+	for _, req := range requests {
+		results = append(results, BatchResult{
+			RetailerID: req.RetailerID,
+			Handle:     handle,
+			FBTraceID:  traceID,
+			Err:        itemErrors[req.RetailerID],
+		})
+	}
+	return results
+}
+
+// CreateProductSet creates a product set in a catalog from a filter and
+// returns the product set ID
+func (c *Client) CreateProductSet(ctx context.Context, account *Account, catalogID, name string, filter ProductSetFilter) (string, error) {
+	if name == "" {
+		return "", fmt.Errorf("product set name is required")
+	}
+
+	filterJSON, err := buildProductSetFilter(filter)
+	if err != nil {
+		return "", err
+	}
+
+	body := map[string]interface{}{
+		"name":   name,
+		"filter": filterJSON,
+	}
+
+	respBody, _, err := c.doCatalogWrite(ctx, http.MethodPost, c.buildProductSetsURL(account, catalogID), body, account)
+	if err != nil {
+		return "", err
+	}
+
+	var resp ProductCreateResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.ID, nil
+}
+
+// ListProductSets lists all product sets in a catalog, following pagination
+func (c *Client) ListProductSets(ctx context.Context, account *Account, catalogID string) ([]ProductSet, error) {
+	params := url.Values{}
+	params.Add("fields", "id,name,filter,product_count")
+	return listAll[ProductSet](ctx, c, account, c.buildProductSetsURL(account, catalogID), params)
+}
+
+// buildProductSetFilter encodes a ProductSetFilter as the JSON filter string Meta expects
+func buildProductSetFilter(filter ProductSetFilter) (string, error) {
+	var conditions []map[string]interface{}
+	if len(filter.RetailerIDs) > 0 {
+		conditions = append(conditions, map[string]interface{}{
+			"retailer_id": map[string]interface{}{"is_any": filter.RetailerIDs},
+		})
+	}
+	if filter.ProductType != "" {
+		conditions = append(conditions, map[string]interface{}{
+			"product_type": map[string]interface{}{"i_contains": filter.ProductType},
+		})
+	}
+
+	if len(conditions) == 0 {
+		return "", fmt.Errorf("product set filter requires at least one condition")
+	}
+	return marshalProductFilter(conditions)
+}
+
+// marshalProductFilter encodes filter conditions, joining several with "and"
+func marshalProductFilter(conditions []map[string]interface{}) (string, error) {
+	var expr interface{} = conditions[0]
+	if len(conditions) > 1 {
+		expr = map[string]interface{}{"and": conditions}
+	}
+
+	filterJSON, err := json.Marshal(expr)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal filter: %w", err)
+	}
+	return string(filterJSON), nil
+}
+
+// CreateProductGroup creates a product group (item group) in a catalog and
+// returns its ID. Products created with the same RetailerProductGroupID are
+// shown as one listing with their variants as options.
+func (c *Client) CreateProductGroup(ctx context.Context, account *Account, catalogID, retailerID string) (string, error) {
+	if err := validateRetailerID(retailerID); err != nil {
+		return "", err
+	}
+
+	body := map[string]interface{}{
+		"retailer_id": retailerID,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/product_groups", c.getBaseURL(), account.APIVersion, catalogID)
+	respBody, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+	if err != nil {
+		return "", err
+	}
+
+	var resp ProductCreateResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.ID, nil
+}
+
+// ListProductGroupProducts lists the products (variants) in a product group,
+// following pagination. productGroupID is the ID returned by CreateProductGroup,
+// and fields works as for ListCatalogProducts.
+func (c *Client) ListProductGroupProducts(ctx context.Context, account *Account, productGroupID string, fields ...string) ([]ProductInfo, error) {
+	params := url.Values{}
+	params.Add("fields", selectFields(productFields, fields))
+	params.Add("limit", strconv.Itoa(productListPageLimit))
+	apiURL := fmt.Sprintf("%s/%s/%s/products", c.getBaseURL(), account.APIVersion, productGroupID)
+	return listAll[ProductInfo](ctx, c, account, apiURL, params)
+}
+
+// SearchProducts lists the catalog products matching opts, following
+// pagination until the last page. Filtering happens on Meta's side using the
+// Graph API filter DSL: each condition is {"<field>":{"<operator>":<value>}}
+// with operators such as eq, neq, lt, lte, gt, gte, i_contains, is_any and
+// is_not_any, and conditions are combined with {"and":[...]} or {"or":[...]}.
+// Prices are compared in minor units via the price_amount field. The products
+// edge does not support sorting, so results come back in Meta's order.
+func (c *Client) SearchProducts(ctx context.Context, account *Account, catalogID string, opts ProductSearchOptions) ([]ProductInfo, error) {
+	filter, err := buildProductSearchFilter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Add("fields", selectFields(productFields, opts.Fields))
+	params.Add("limit", strconv.Itoa(productListPageLimit))
+	if filter != "" {
+		params.Add("filter", filter)
+	}
+	return listAll[ProductInfo](ctx, c, account, c.buildCatalogProductsURL(account, catalogID), params)
+}
+
+// buildProductSearchFilter validates opts and encodes them as a Graph API
+// filter. It returns an empty string when opts has no conditions.
+func buildProductSearchFilter(opts ProductSearchOptions) (string, error) {
+	var conditions []map[string]interface{}
+
+	if len(opts.RetailerIDs) > 0 {
+		for _, id := range opts.RetailerIDs {
+			if id == "" {
+				return "", fmt.Errorf("retailer ID filter contains an empty value")
+			}
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"retailer_id": map[string]interface{}{"is_any": opts.RetailerIDs},
+		})
+	}
+
+	if opts.Availability != "" {
+		if err := validateAvailability(opts.Availability); err != nil {
+			return "", err
+		}
+		conditions = append(conditions, map[string]interface{}{
+			"availability": map[string]interface{}{"eq": opts.Availability},
+		})
+	}
+
+	if opts.MinPrice < 0 || opts.MaxPrice < 0 {
+		return "", fmt.Errorf("price bounds must not be negative")
+	}
+	if opts.MaxPrice > 0 && opts.MinPrice > opts.MaxPrice {
+		return "", fmt.Errorf("min price %d exceeds max price %d", opts.MinPrice, opts.MaxPrice)
+	}
+	if opts.MinPrice > 0 {
+		conditions = append(conditions, map[string]interface{}{
+			"price_amount": map[string]interface{}{"gte": opts.MinPrice},
+		})
+	}
+	if opts.MaxPrice > 0 {
+		conditions = append(conditions, map[string]interface{}{
+			"price_amount": map[string]interface{}{"lte": opts.MaxPrice},
+		})
+	}
+
+	if opts.Filter != "" {
+		var raw map[string]interface{}
+		if err := json.Unmarshal([]byte(opts.Filter), &raw); err != nil {
+			return "", fmt.Errorf("invalid product filter: must be a JSON object: %w", err)
+		}
+		if len(raw) == 0 {
+			return "", fmt.Errorf("invalid product filter: empty object")
+		}
+		conditions = append(conditions, raw)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return marshalProductFilter(conditions)
+}
@@ -3,9 +3,13 @@ package whatsapp_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shridarpatil/whatomate/pkg/whatsapp"
 	"github.com/stretchr/testify/assert"
@@ -97,6 +101,92 @@ func TestClient_ListCatalogs_Success(t *testing.T) {
 	assert.Equal(t, "Catalog 1", catalogs[0].Name)
 }
 
+func TestClient_ListCatalogs_ProductCountAndVertical(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/987654321/owned_product_catalogs", r.URL.Path)
+		assert.Equal(t, "id,name,product_count,vertical", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "cat-1", "name": "Catalog 1", "product_count": 42, "vertical": "commerce"},
+				{"id": "cat-2", "name": "Catalog 2", "product_count": 0},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	catalogs, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	require.Len(t, catalogs, 2)
+	assert.Equal(t, 42, catalogs[0].ProductCount)
+	assert.Equal(t, "commerce", catalogs[0].Vertical)
+	assert.Zero(t, catalogs[1].ProductCount)
+	assert.Empty(t, catalogs[1].Vertical)
+}
+
+func TestClient_ListCatalogs_FollowsPaging(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "cat-1"}, {"id": "cat-2"}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "cursor-2"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		assert.Equal(t, "cursor-2", r.URL.Query().Get("after"))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "cat-3"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	catalogs, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	require.Len(t, catalogs, 3)
+	assert.Equal(t, "cat-3", catalogs[2].ID)
+}
+
+func TestClient_ListCatalogsPaginated(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "cursor-1", r.URL.Query().Get("after"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "cat-11"}},
+			"paging": map[string]interface{}{
+				"cursors": map[string]string{"after": "cursor-2"},
+				"next":    "https://graph.facebook.com/next",
+			},
+			"summary": map[string]interface{}{"total_count": 25},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	page, err := client.ListCatalogsPaginated(context.Background(), testAccount(server.URL), "cursor-1", 10)
+	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "cursor-2", page.NextCursor)
+	assert.True(t, page.HasMore())
+	assert.Equal(t, 25, page.TotalCount)
+}
+
 func TestClient_ListCatalogs_Empty(t *testing.T) {
 	t.Parallel()
 
@@ -114,6 +204,147 @@ func TestClient_ListCatalogs_Empty(t *testing.T) {
 	assert.Empty(t, catalogs)
 }
 
+// --- ConnectCatalogToWABA / GetConnectedCatalog ---
+
+func TestClient_ConnectCatalogToWABA_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/987654321/product_catalogs", r.URL.Path)
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "cat-1", body["catalog_id"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.ConnectCatalogToWABA(context.Background(), testAccount(server.URL), "cat-1")
+	require.NoError(t, err)
+}
+
+func TestClient_ConnectCatalogToWABA_NotOwned(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"message":"(#200) Requires business_management permission to manage the object","type":"OAuthException","code":200}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.ConnectCatalogToWABA(context.Background(), testAccount(server.URL), "cat-foreign")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, whatsapp.ErrCatalogNotOwned)
+	assert.Contains(t, err.Error(), "cat-foreign")
+}
+
+func TestClient_GetConnectedCatalog(t *testing.T) {
+	t.Parallel()
+
+	connected := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/987654321/product_catalogs", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		data := []map[string]string{}
+		if connected {
+			data = append(data, map[string]string{"id": "cat-1", "name": "Main"})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	catalogID, err := client.GetConnectedCatalog(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	assert.Equal(t, "cat-1", catalogID)
+
+	connected = false
+	catalogID, err = client.GetConnectedCatalog(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	assert.Empty(t, catalogID)
+}
+
+// --- GetCatalog ---
+
+func TestClient_GetCatalog_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/cat-1", r.URL.Path)
+		assert.Equal(t, "id,name,product_count,vertical", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": "cat-1", "name": "Summer", "product_count": 12, "vertical": "commerce",
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	catalog, err := client.GetCatalog(context.Background(), testAccount(server.URL), "cat-1")
+	require.NoError(t, err)
+	assert.Equal(t, &whatsapp.CatalogInfo{ID: "cat-1", Name: "Summer", ProductCount: 12, Vertical: "commerce"}, catalog)
+}
+
+func TestClient_GetCatalog_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Unsupported get request. Object with ID 'bad' does not exist","code":100,"error_subcode":33}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.GetCatalog(context.Background(), testAccount(server.URL), "bad")
+	require.ErrorIs(t, err, whatsapp.ErrCatalogNotFound)
+	assert.ErrorIs(t, err, whatsapp.ErrNotFound)
+}
+
+// --- UpdateCatalog ---
+
+func TestClient_UpdateCatalog_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/cat-1", r.URL.Path)
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]string{"name": "Winter"}, body)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.UpdateCatalog(context.Background(), testAccount(server.URL), "cat-1", "Winter")
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateCatalog_EmptyName(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("empty name should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.UpdateCatalog(context.Background(), testAccount(server.URL), "cat-1", "  ")
+	require.Error(t, err)
+}
+
 // --- DeleteCatalog ---
 
 func TestClient_DeleteCatalog_Success(t *testing.T) {
@@ -179,118 +410,1402 @@ func TestClient_ListCatalogProducts_Empty(t *testing.T) {
 	assert.Empty(t, products)
 }
 
-// --- CreateProduct ---
+func TestClient_ListCatalogProducts_Fields(t *testing.T) {
+	t.Parallel()
 
-func TestClient_CreateProduct_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "id,price", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-1", "price": "$19.99"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	products, err := client.ListCatalogProducts(context.Background(), testAccount(server.URL), "catalog-123", "id", "price")
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "$19.99", products[0].Price)
+	assert.Empty(t, products[0].Name)
+}
+
+func TestClient_ListCatalogProducts_FollowsPaging(t *testing.T) {
 	t.Parallel()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, http.MethodPost, r.Method)
-		assert.Contains(t, r.URL.Path, "/products")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "prod-1"}, {"id": "prod-2"}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "cursor-2"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		assert.Equal(t, "cursor-2", r.URL.Query().Get("after"))
+		// Last page still carries an after cursor but no next link
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-3"}},
+			"paging": map[string]interface{}{
+				"cursors": map[string]string{"after": "cursor-3"},
+			},
+		})
+	}))
+	defer server.Close()
 
-		var body map[string]interface{}
-		_ = json.NewDecoder(r.Body).Decode(&body)
-		assert.Equal(t, "Test Product", body["name"])
-		assert.Equal(t, "USD", body["currency"])
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	products, err := client.ListCatalogProducts(context.Background(), account, "catalog-123")
+	require.NoError(t, err)
+	require.Len(t, products, 3)
+	assert.Equal(t, "prod-3", products[2].ID)
+}
+
+func TestClient_IterateProducts(t *testing.T) {
+	t.Parallel()
 
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-new"})
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "prod-1"}, {"id": "prod-2"}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "cursor-2"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-3"}},
+		})
 	}))
 	defer server.Close()
 
 	client := newTestClient(t, server)
 	account := testAccount(server.URL)
 
-	product := &whatsapp.ProductInput{
-		Name:        "Test Product",
-		Price:       1999,
-		Currency:    "USD",
-		URL:         "https://example.com/product",
-		ImageURL:    "https://example.com/image.jpg",
-		RetailerID:  "SKU-001",
-		Description: "A test product",
+	var ids []string
+	for product, err := range client.IterateProducts(context.Background(), account, "catalog-123") {
+		require.NoError(t, err)
+		ids = append(ids, product.ID)
 	}
+	assert.Equal(t, []string{"prod-1", "prod-2", "prod-3"}, ids)
+	assert.Equal(t, 2, requests)
 
-	id, err := client.CreateProduct(context.Background(), account, "catalog-123", product)
-	require.NoError(t, err)
-	assert.Equal(t, "prod-new", id)
+	// Breaking out early does not fetch further pages
+	requests = 0
+	for product := range client.IterateProducts(context.Background(), account, "catalog-123") {
+		assert.Equal(t, "prod-1", product.ID)
+		break
+	}
+	assert.Equal(t, 1, requests)
 }
 
-// --- UpdateProduct ---
-
-func TestClient_UpdateProduct_Success(t *testing.T) {
+func TestClient_IterateProducts_Canceled(t *testing.T) {
 	t.Parallel()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-1"}, {"id": "prod-2"}},
+		})
+	}))
+	defer server.Close()
 
-		var body map[string]interface{}
-		_ = json.NewDecoder(r.Body).Decode(&body)
-		assert.Equal(t, "Updated Product", body["name"])
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var ids []string
+	var iterErr error
+	for product, err := range client.IterateProducts(ctx, testAccount(server.URL), "catalog-123") {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		ids = append(ids, product.ID)
+		cancel()
+	}
+	assert.Equal(t, []string{"prod-1"}, ids)
+	assert.ErrorIs(t, iterErr, context.Canceled)
+}
+
+func TestClient_ListCatalogProductsPaginated(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "cursor-1", r.URL.Query().Get("after"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "total_count", r.URL.Query().Get("summary"))
 
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-1"}},
+			"paging": map[string]interface{}{
+				"cursors": map[string]string{"after": "cursor-2"},
+				"next":    "https://graph.facebook.com/next",
+			},
+		})
 	}))
 	defer server.Close()
 
 	client := newTestClient(t, server)
 	account := testAccount(server.URL)
 
-	product := &whatsapp.ProductInput{
-		Name:  "Updated Product",
-		Price: 2999,
-	}
-
-	err := client.UpdateProduct(context.Background(), account, "prod-123", product)
+	page, err := client.ListCatalogProductsPaginated(context.Background(), account, "catalog-123", "cursor-1", 10)
 	require.NoError(t, err)
+	require.Len(t, page.Data, 1)
+	assert.Equal(t, "cursor-2", page.NextCursor)
+	assert.Equal(t, -1, page.TotalCount)
 }
 
-// --- DeleteProduct ---
+// --- SearchProducts ---
 
-func TestClient_DeleteProduct_Success(t *testing.T) {
+func TestClient_CreateProductGroup(t *testing.T) {
 	t.Parallel()
 
+	var productBody map[string]interface{}
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		assert.Equal(t, http.MethodDelete, r.Method)
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+		switch r.URL.Path {
+		case "/v21.0/catalog-123/product_groups":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "TSHIRT", body["retailer_id"])
+			_, _ = w.Write([]byte(`{"id":"group-1"}`))
+		case "/v21.0/catalog-123/products":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&productBody))
+			_, _ = w.Write([]byte(`{"id":"prod-1"}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
 	}))
 	defer server.Close()
 
 	client := newTestClient(t, server)
 	account := testAccount(server.URL)
 
-	err := client.DeleteProduct(context.Background(), account, "prod-123")
+	groupID, err := client.CreateProductGroup(context.Background(), account, "catalog-123", "TSHIRT")
+	require.NoError(t, err)
+	assert.Equal(t, "group-1", groupID)
+
+	_, err = client.CreateProduct(context.Background(), account, "catalog-123", &whatsapp.ProductInput{
+		Name: "T-Shirt (M)", Price: 1999, Currency: "USD", RetailerID: "TSHIRT-M", RetailerProductGroupID: "TSHIRT",
+	})
 	require.NoError(t, err)
+	assert.Equal(t, "TSHIRT", productBody["retailer_product_group_id"])
 }
 
-func TestClient_DeleteProduct_APIError(t *testing.T) {
+func TestClient_ListProductGroupProducts(t *testing.T) {
 	t.Parallel()
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusNotFound)
-		_ = json.NewEncoder(w).Encode(whatsapp.MetaAPIError{
-			Error: struct {
-				Message      string `json:"message"`
-				Type         string `json:"type"`
-				Code         int    `json:"code"`
-				ErrorSubcode int    `json:"error_subcode"`
-				ErrorUserMsg string `json:"error_user_msg"`
-				ErrorData    struct {
-					Details string `json:"details"`
-				} `json:"error_data"`
-				FBTraceID string `json:"fbtrace_id"`
-			}{
-				Message: "Product not found",
-				Code:    100,
+		assert.Equal(t, "/v21.0/group-1/products", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("fields"), "retailer_product_group_id")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]string{
+				{"id": "prod-1", "retailer_id": "TSHIRT-M", "retailer_product_group_id": "TSHIRT"},
+				{"id": "prod-2", "retailer_id": "TSHIRT-L", "retailer_product_group_id": "TSHIRT"},
 			},
 		})
 	}))
 	defer server.Close()
 
 	client := newTestClient(t, server)
-	account := testAccount(server.URL)
+	products, err := client.ListProductGroupProducts(context.Background(), testAccount(server.URL), "group-1")
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+	assert.Equal(t, "TSHIRT-L", products[1].RetailerID)
+	assert.Equal(t, "TSHIRT", products[1].RetailerProductGroupID)
+}
 
-	err := client.DeleteProduct(context.Background(), account, "nonexistent")
-	require.Error(t, err)
+func TestClient_SearchProducts_BuildsFilter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/cat-1/products", r.URL.Path)
+		assert.Equal(t, "id,name", r.URL.Query().Get("fields"))
+
+		var filter map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter))
+		assert.Equal(t, map[string]interface{}{"and": []interface{}{
+			map[string]interface{}{"retailer_id": map[string]interface{}{"is_any": []interface{}{"SKU-1", "SKU-2"}}},
+			map[string]interface{}{"availability": map[string]interface{}{"eq": "in stock"}},
+			map[string]interface{}{"price_amount": map[string]interface{}{"gte": float64(500)}},
+			map[string]interface{}{"price_amount": map[string]interface{}{"lte": float64(2000)}},
+			map[string]interface{}{"brand": map[string]interface{}{"i_contains": "acme"}},
+		}}, filter)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]string{{"id": "p1", "name": "Mug"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	products, err := client.SearchProducts(context.Background(), testAccount(server.URL), "cat-1", whatsapp.ProductSearchOptions{
+		RetailerIDs:  []string{"SKU-1", "SKU-2"},
+		Availability: whatsapp.ProductAvailabilityInStock,
+		MinPrice:     500,
+		MaxPrice:     2000,
+		Filter:       `{"brand":{"i_contains":"acme"}}`,
+		Fields:       []string{"id", "name"},
+	})
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Mug", products[0].Name)
+}
+
+func TestClient_SearchProducts_NoFilter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.False(t, r.URL.Query().Has("filter"))
+		assert.Contains(t, r.URL.Query().Get("fields"), "retailer_id")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	products, err := client.SearchProducts(context.Background(), testAccount(server.URL), "cat-1", whatsapp.ProductSearchOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, products)
+}
+
+func TestClient_SearchProducts_InvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid search should not reach the API")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts whatsapp.ProductSearchOptions
+	}{
+		{"empty retailer ID", whatsapp.ProductSearchOptions{RetailerIDs: []string{""}}},
+		{"invalid availability", whatsapp.ProductSearchOptions{Availability: "IN_STOCK"}},
+		{"negative price", whatsapp.ProductSearchOptions{MinPrice: -1}},
+		{"inverted price range", whatsapp.ProductSearchOptions{MinPrice: 2000, MaxPrice: 500}},
+		{"malformed filter", whatsapp.ProductSearchOptions{Filter: `{"brand":`}},
+		{"non-object filter", whatsapp.ProductSearchOptions{Filter: `["brand"]`}},
+		{"empty filter", whatsapp.ProductSearchOptions{Filter: `{}`}},
+	}
+
+	client := newTestClient(t, server)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SearchProducts(context.Background(), testAccount(server.URL), "cat-1", tt.opts)
+			require.Error(t, err)
+		})
+	}
+}
+
+// --- GetProduct ---
+
+func TestClient_GetProduct_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Contains(t, r.URL.Path, "/prod-123")
+		assert.Contains(t, r.URL.Query().Get("fields"), "retailer_id")
+		assert.Contains(t, r.URL.Query().Get("fields"), "additional_image_urls")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id": "prod-123", "name": "Product 1", "price": "$19.99", "currency": "USD",
+			"additional_image_urls": []string{"https://example.com/2.jpg", "https://example.com/3.jpg"},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	product, err := client.GetProduct(context.Background(), account, "prod-123")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-123", product.ID)
+	assert.Equal(t, "$19.99", product.Price)
+	assert.Equal(t, []string{"https://example.com/2.jpg", "https://example.com/3.jpg"}, product.AdditionalImageURLs)
+}
+
+func TestClient_GetProduct_Fields(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// id is always requested, as it tells a missing product apart
+		assert.Equal(t, "price,availability,id", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "prod-123", "price": "$19.99", "availability": "in stock"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	product, err := client.GetProduct(context.Background(), testAccount(server.URL), "prod-123", "price", "availability")
+	require.NoError(t, err)
+	assert.Equal(t, "in stock", product.Availability)
+}
+
+func TestClient_GetProduct_EmptyResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	product, err := client.GetProduct(context.Background(), account, "missing")
+	require.Error(t, err)
+	assert.Nil(t, product)
+	assert.Contains(t, err.Error(), "not found")
+	assert.ErrorIs(t, err, whatsapp.ErrProductNotFound)
+}
+
+func TestClient_GetProduct_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Unsupported get request. Object with ID 'missing' does not exist, cannot be loaded due to missing permissions, or does not support this operation.","type":"GraphMethodException","code":100,"error_subcode":33,"fbtrace_id":"AbCdEf"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	product, err := client.GetProduct(context.Background(), testAccount(server.URL), "missing")
+	require.ErrorIs(t, err, whatsapp.ErrProductNotFound)
+	assert.ErrorIs(t, err, whatsapp.ErrNotFound)
+	assert.Nil(t, product)
+	assert.Contains(t, err.Error(), "missing")
+}
+
+// --- GetProductByRetailerID ---
+
+func TestClient_GetProductByRetailerID_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/catalog-1/products", r.URL.Path)
+		assert.JSONEq(t, `{"retailer_id":{"eq":"SKU-42"}}`, r.URL.Query().Get("filter"))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{
+				{"id": "prod-42", "name": "Blue Shirt", "retailer_id": "SKU-42"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	product, err := client.GetProductByRetailerID(context.Background(), testAccount(server.URL), "catalog-1", "SKU-42")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-42", product.ID)
+	assert.Equal(t, "Blue Shirt", product.Name)
+}
+
+func TestClient_GetProductByRetailerID_NotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": []interface{}{}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	product, err := client.GetProductByRetailerID(context.Background(), testAccount(server.URL), "catalog-1", "SKU-404")
+	require.Error(t, err)
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, whatsapp.ErrProductNotFound)
+}
+
+// --- CreateProduct ---
+
+func TestClient_CreateProduct_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Contains(t, r.URL.Path, "/products")
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Test Product", body["name"])
+		assert.Equal(t, "USD", body["currency"])
+		assert.Equal(t, "19.99", body["price"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-new"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	product := &whatsapp.ProductInput{
+		Name:        "Test Product",
+		Price:       1999,
+		Currency:    "USD",
+		URL:         "https://example.com/product",
+		ImageURL:    "https://example.com/image.jpg",
+		RetailerID:  "SKU-001",
+		Description: "A test product",
+	}
+
+	id, err := client.CreateProduct(context.Background(), account, "catalog-123", product)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-new", id)
+}
+
+func TestClient_CreateProduct_WithVariants(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		// Variant attributes are sent as a JSON-encoded string
+		raw, ok := body["additional_variant_attributes"].(string)
+		require.True(t, ok)
+		var variants []whatsapp.VariantAttribute
+		require.NoError(t, json.Unmarshal([]byte(raw), &variants))
+		assert.Equal(t, []whatsapp.VariantAttribute{
+			{Name: "size", Value: "M"},
+			{Name: "color", Value: "Blue"},
+		}, variants)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-variant"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	product := &whatsapp.ProductInput{
+		Name:       "T-Shirt",
+		Price:      1999,
+		Currency:   "USD",
+		RetailerID: "TSHIRT-M-BLUE",
+		Variants: []whatsapp.VariantAttribute{
+			{Name: "size", Value: "M"},
+			{Name: "color", Value: "Blue"},
+		},
+	}
+
+	id, err := client.CreateProduct(context.Background(), account, "catalog-123", product)
+	require.NoError(t, err)
+	assert.Equal(t, "prod-variant", id)
+}
+
+func TestClient_CreateProduct_WithoutVariants(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.NotContains(t, body, "additional_variant_attributes")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-plain"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	_, err := client.CreateProduct(context.Background(), account, "catalog-123", &whatsapp.ProductInput{
+		Name:       "Mug",
+		Price:      999,
+		Currency:   "USD",
+		RetailerID: "SKU-MUG",
+	})
+	require.NoError(t, err)
+}
+
+// --- UpdateProduct ---
+
+func TestClient_CreateProduct_AvailabilityAndCondition(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "out of stock", body["availability"])
+		assert.Equal(t, "refurbished", body["condition"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-new"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	product := &whatsapp.ProductInput{
+		Name:         "Test Product",
+		Price:        1999,
+		Currency:     "USD",
+		RetailerID:   "SKU-001",
+		Availability: whatsapp.ProductAvailabilityOutOfStock,
+		Condition:    whatsapp.ProductConditionRefurbished,
+	}
+
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", product)
+	require.NoError(t, err)
+}
+
+func TestClient_CreateProduct_InvalidAvailabilityOrCondition(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid product should not reach the API")
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &whatsapp.ProductInput{
+		Name: "Test", Availability: "sold out",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid product availability")
+
+	err = client.UpdateProduct(context.Background(), testAccount(server.URL), "prod-1", &whatsapp.ProductInput{
+		Condition: "mint",
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid product condition")
+}
+
+// newUpsertServer serves a catalog holding the given products (retailer ID -> product ID)
+// and records the method and path of each write
+func newUpsertServer(t *testing.T, products map[string]string, writes *[]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			var filter struct {
+				RetailerID struct {
+					Eq string `json:"eq"`
+				} `json:"retailer_id"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("filter")), &filter))
+			data := []map[string]string{}
+			if id, ok := products[filter.RetailerID.Eq]; ok {
+				data = append(data, map[string]string{"id": id, "retailer_id": filter.RetailerID.Eq})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+			return
+		}
+		*writes = append(*writes, r.Method+" "+r.URL.Path)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "prod-new", "success": true})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_UpsertProduct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		retailerID string
+		wantID     string
+		wantWrite  string
+	}{
+		{"creates new product", "SKU-NEW", "prod-new", "POST /v21.0/catalog-1/products"},
+		{"updates existing product", "SKU-1", "prod-1", "POST /v21.0/prod-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var writes []string
+			server := newUpsertServer(t, map[string]string{"SKU-1": "prod-1"}, &writes)
+			client := newTestClient(t, server)
+
+			id, err := client.UpsertProduct(context.Background(), testAccount(server.URL), "catalog-1", &whatsapp.ProductInput{
+				Name: "Mug", Price: 1299, Currency: "USD", RetailerID: tt.retailerID,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, id)
+			assert.Equal(t, []string{tt.wantWrite}, writes)
+		})
+	}
+}
+
+func TestClient_UpsertProduct_RequiresRetailerID(t *testing.T) {
+	t.Parallel()
+
+	var writes []string
+	server := newUpsertServer(t, nil, &writes)
+	client := newTestClient(t, server)
+
+	_, err := client.UpsertProduct(context.Background(), testAccount(server.URL), "catalog-1", &whatsapp.ProductInput{Name: "Mug"})
+	require.Error(t, err)
+	assert.Empty(t, writes)
+}
+
+func TestClient_CreateProduct_CustomLabelsAndData(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "summer", body["custom_label_0"])
+		assert.Equal(t, "clearance", body["custom_label_3"])
+		assert.NotContains(t, body, "custom_label_1")
+		assert.Equal(t, map[string]interface{}{"season": "2024", "margin": "high"}, body["custom_data"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &whatsapp.ProductInput{
+		Name:         "Sandals",
+		Price:        2500,
+		Currency:     "USD",
+		RetailerID:   "SKU-9",
+		CustomLabels: [5]string{0: "summer", 3: "clearance"},
+		CustomData:   map[string]string{"season": "2024", "margin": "high"},
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_CreateProduct_SalePrice(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "19.99", body["price"])
+		assert.Equal(t, "14.99", body["sale_price"])
+		assert.Equal(t, "2024-06-01T00:00+00:00/2024-06-30T23:59+00:00", body["sale_price_effective_date"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &whatsapp.ProductInput{
+		Name:           "Sandals",
+		Price:          1999,
+		Currency:       "USD",
+		RetailerID:     "SKU-9",
+		SalePrice:      1499,
+		SalePriceStart: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		SalePriceEnd:   time.Date(2024, 6, 30, 23, 59, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_CreateProduct_InvalidSalePrice(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid sale price should not reach the API")
+	}))
+	defer server.Close()
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 30)
+	tests := []struct {
+		name    string
+		product whatsapp.ProductInput
+		wantErr string
+	}{
+		{"not cheaper", whatsapp.ProductInput{Price: 1000, SalePrice: 1000}, "must be lower than price"},
+		{"period without sale", whatsapp.ProductInput{Price: 1000, SalePriceStart: start, SalePriceEnd: end}, "requires a sale price"},
+		{"open period", whatsapp.ProductInput{Price: 1000, SalePrice: 500, SalePriceStart: start}, "both start and end"},
+		{"inverted period", whatsapp.ProductInput{Price: 1000, SalePrice: 500, SalePriceStart: end, SalePriceEnd: start}, "start must be before end"},
+	}
+
+	client := newTestClient(t, server)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := tt.product
+			product.Name, product.Currency, product.RetailerID = "Sandals", "USD", "SKU-9"
+			_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &product)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClient_CreateProduct_AdditionalImages(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, []interface{}{"https://example.com/back.jpg", "https://example.com/side.jpg"}, body["additional_image_urls"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"prod-1"}`))
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	product := &whatsapp.ProductInput{
+		Name: "Jacket", Price: 4999, Currency: "USD", RetailerID: "JACKET-1",
+		ImageURL:            "https://example.com/front.jpg",
+		AdditionalImageURLs: []string{"https://example.com/back.jpg", "https://example.com/side.jpg"},
+	}
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", product)
+	require.NoError(t, err)
+
+	product.AdditionalImageURLs = make([]string, 21)
+	for i := range product.AdditionalImageURLs {
+		product.AdditionalImageURLs[i] = fmt.Sprintf("https://example.com/%d.jpg", i)
+	}
+	_, err = client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", product)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 20 additional images")
+}
+
+func TestClient_CreateProduct_InvalidCurrency(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid currency should not reach the API")
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	for _, currency := range []whatsapp.Currency{"US", "XYZ", ""} {
+		_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &whatsapp.ProductInput{
+			Name: "Mug", Price: 1299, Currency: currency, RetailerID: "SKU-1",
+		})
+		require.Error(t, err, currency)
+		assert.Contains(t, err.Error(), "ISO 4217")
+	}
+
+	err := client.UpdateProduct(context.Background(), testAccount(server.URL), "prod-1", &whatsapp.ProductInput{Currency: "EURO"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid currency")
+}
+
+func TestClient_CreateProduct_InvalidRetailerID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid retailer ID should not reach the API")
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	tests := []struct {
+		retailerID      string
+		wantErrContains string
+	}{
+		{"", "retailer ID is required"},
+		{"SKU 1", `invalid retailer ID "SKU 1"`},
+		{"SKU-1\n", "whitespace or control characters"},
+		{strings.Repeat("a", 101), "exceeds 100 characters"},
+	}
+	for _, tt := range tests {
+		_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "catalog-123", &whatsapp.ProductInput{
+			Name: "Mug", Price: 1299, Currency: "USD", RetailerID: tt.retailerID,
+		})
+		require.Error(t, err, tt.retailerID)
+		assert.Contains(t, err.Error(), tt.wantErrContains)
+	}
+}
+
+func TestClient_UpdateProduct_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "Updated Product", body["name"])
+		assert.Equal(t, "29.99", body["price"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	product := &whatsapp.ProductInput{
+		Name:  "Updated Product",
+		Price: 2999,
+	}
+
+	err := client.UpdateProduct(context.Background(), account, "prod-123", product)
+	require.NoError(t, err)
+}
+
+// --- SetProductAvailability ---
+
+func TestClient_SetProductAvailability_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/prod-1", r.URL.Path)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"availability": "out of stock"}, body)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.SetProductAvailability(context.Background(), testAccount(server.URL), "prod-1", whatsapp.ProductAvailabilityOutOfStock)
+	require.NoError(t, err)
+}
+
+func TestClient_SetProductAvailability_InvalidValue(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid availability should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.SetProductAvailability(context.Background(), testAccount(server.URL), "prod-1", "OUT_OF_STOCK")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid product availability")
+}
+
+func TestParseAvailability(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]whatsapp.Availability{
+		"in stock":             whatsapp.ProductAvailabilityInStock,
+		"OUT_OF_STOCK":         whatsapp.ProductAvailabilityOutOfStock,
+		" Available for order": whatsapp.ProductAvailabilityAvailableToOrder,
+	} {
+		got, err := whatsapp.ParseAvailability(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got)
+		assert.True(t, got.Valid())
+	}
+
+	_, err := whatsapp.ParseAvailability("sold out")
+	require.Error(t, err)
+	assert.False(t, whatsapp.Availability("sold out").Valid())
+}
+
+// --- UploadCatalogImage ---
+
+func TestClient_UploadCatalogImage_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/v21.0/app-1/uploads":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			assert.Equal(t, "image/png", body["file_type"])
+			assert.Equal(t, float64(4), body["file_length"])
+			_ = json.NewEncoder(w).Encode(map[string]string{"id": "upload:session-1"})
+		case "/v21.0/upload:session-1":
+			assert.Equal(t, "OAuth test-access-token", r.Header.Get("Authorization"))
+			data, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "\x89PNG", string(data))
+			_ = json.NewEncoder(w).Encode(map[string]string{"h": "4::aW1hZ2UtaGFuZGxlLWNhdGFsb2c="})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	account := testAccount(server.URL)
+	account.AppID = "app-1"
+
+	client := newTestClient(t, server)
+	handle, err := client.UploadCatalogImage(context.Background(), account, "cat-1", strings.NewReader("\x89PNG"), "image/png")
+	require.NoError(t, err)
+	assert.Equal(t, "4::aW1hZ2UtaGFuZGxlLWNhdGFsb2c=", handle)
+}
+
+func TestClient_UploadCatalogImage_Handle(t *testing.T) {
+	t.Parallel()
+
+	for name, tt := range map[string]struct {
+		handle  string
+		wantErr bool
+	}{
+		"short handle": {handle: "4::abc"},
+		"empty handle": {handle: "", wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				if r.URL.Path == "/v21.0/app-1/uploads" {
+					_ = json.NewEncoder(w).Encode(map[string]string{"id": "upload:session-1"})
+					return
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"h": tt.handle})
+			}))
+			defer server.Close()
+
+			account := testAccount(server.URL)
+			account.AppID = "app-1"
+
+			client := newTestClient(t, server)
+			handle, err := client.UploadCatalogImage(context.Background(), account, "cat-1", strings.NewReader("\x89PNG"), "image/png")
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "no handle")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.handle, handle)
+		})
+	}
+}
+
+func TestClient_UploadCatalogImage_UnsupportedType(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unsupported image should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.UploadCatalogImage(context.Background(), testAccount(server.URL), "cat-1", strings.NewReader("GIF89a"), "image/gif")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported catalog image type")
+}
+
+func TestClient_CreateProduct_ImageHandle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "4::handle", body["image_handle"])
+		assert.NotContains(t, body, "image_url")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "prod-1"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateProduct(context.Background(), testAccount(server.URL), "cat-1", &whatsapp.ProductInput{
+		Name:        "Mug",
+		Price:       1299,
+		Currency:    "USD",
+		RetailerID:  "SKU-1",
+		ImageHandle: "4::handle",
+	})
+	require.NoError(t, err)
+}
+
+// --- UpdateProductFields ---
+
+func TestClient_UpdateProductFields_ClearsOptionalFields(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/prod-1", r.URL.Path)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{"url": "", "name": "Renamed"}, body)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	empty, name := "", "Renamed"
+	client := newTestClient(t, server)
+	err := client.UpdateProductFields(context.Background(), testAccount(server.URL), "prod-1", map[string]*string{
+		"url":         &empty,
+		"name":        &name,
+		"description": nil,
+	})
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateProductFields_Invalid(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid fields should not reach the API")
+	}))
+	defer server.Close()
+
+	empty, bad := "", "OUT_OF_STOCK"
+	tests := []struct {
+		name    string
+		fields  map[string]*string
+		wantErr string
+	}{
+		{"unknown field", map[string]*string{"color": &empty}, "unsupported product field"},
+		{"clear required field", map[string]*string{"name": &empty}, "cannot be cleared"},
+		{"invalid availability", map[string]*string{"availability": &bad}, "invalid product availability"},
+		{"nothing to update", map[string]*string{"url": nil}, "no product fields"},
+	}
+
+	client := newTestClient(t, server)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := client.UpdateProductFields(context.Background(), testAccount(server.URL), "prod-1", tt.fields)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+// --- DeleteProduct ---
+
+func TestClient_DeleteProduct_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	err := client.DeleteProduct(context.Background(), account, "prod-123")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteProduct_APIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(whatsapp.MetaAPIError{
+			Error: struct {
+				Message      string `json:"message"`
+				Type         string `json:"type"`
+				Code         int    `json:"code"`
+				ErrorSubcode int    `json:"error_subcode"`
+				ErrorUserMsg string `json:"error_user_msg"`
+				ErrorData    struct {
+					Details string `json:"details"`
+				} `json:"error_data"`
+				FBTraceID string `json:"fbtrace_id"`
+			}{
+				Message: "Product not found",
+				Code:    100,
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	err := client.DeleteProduct(context.Background(), account, "nonexistent")
+	require.Error(t, err)
+}
+
+// --- BatchUpsertProducts ---
+
+func TestClient_BatchUpsertProducts_Success(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Contains(t, r.URL.Path, "/catalog-123/batch")
+
+		var body struct {
+			AllowUpsert bool `json:"allow_upsert"`
+			Requests    []struct {
+				Method     string                 `json:"method"`
+				RetailerID string                 `json:"retailer_id"`
+				Data       map[string]interface{} `json:"data"`
+			} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.AllowUpsert)
+		require.Len(t, body.Requests, 2)
+		assert.Equal(t, "UPDATE", body.Requests[0].Method)
+		assert.Equal(t, "SKU-1", body.Requests[0].RetailerID)
+		assert.Equal(t, "Product 1", body.Requests[0].Data["name"])
+		assert.NotContains(t, body.Requests[0].Data, "retailer_id")
+
+		w.Header().Set("X-FB-Trace-ID", "trace-batch-1")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"handles": []string{"handle-1"},
+			"validation_status": []map[string]interface{}{
+				{"retailer_id": "SKU-2", "errors": []map[string]string{{"message": "Invalid price"}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	results, err := client.BatchUpsertProducts(context.Background(), account, "catalog-123", []whatsapp.ProductInput{
+		{Name: "Product 1", Price: 1000, Currency: "USD", RetailerID: "SKU-1"},
+		{Name: "Product 2", Price: -1, Currency: "USD", RetailerID: "SKU-2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "SKU-1", results[0].RetailerID)
+	assert.Equal(t, "handle-1", results[0].Handle)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "SKU-2", results[1].RetailerID)
+	assert.Equal(t, "trace-batch-1", results[1].FBTraceID)
+	require.Error(t, results[1].Err)
+	assert.Contains(t, results[1].Err.Error(), "Invalid price")
+}
+
+func TestClient_BatchUpsertProducts_DuplicateRetailerID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("batch with duplicate retailer IDs should not reach the API")
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	_, err := client.BatchUpsertProducts(context.Background(), testAccount(server.URL), "catalog-123", []whatsapp.ProductInput{
+		{Name: "Mug", Price: 999, Currency: "USD", RetailerID: "SKU-1"},
+		{Name: "Cup", Price: 599, Currency: "USD", RetailerID: "SKU-2"},
+		{Name: "Mug XL", Price: 1299, Currency: "USD", RetailerID: "SKU-1"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate retailer ID "SKU-1" in batch at items 1 and 3`)
+
+	_, err = client.BatchDeleteProducts(context.Background(), testAccount(server.URL), "catalog-123", []string{"SKU-1", "SKU-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate retailer ID "SKU-1"`)
+}
+
+func TestClient_BatchUpsertProducts_SplitsLargeBatches(t *testing.T) {
+	t.Parallel()
+
+	var sizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []json.RawMessage `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		sizes = append(sizes, len(body.Requests))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"handles": []string{"h"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	products := make([]whatsapp.ProductInput, 5001)
+	for i := range products {
+		products[i] = whatsapp.ProductInput{Name: "P", Price: 100, Currency: "USD", RetailerID: fmt.Sprintf("SKU-%d", i)}
+	}
+
+	results, err := client.BatchUpsertProducts(context.Background(), account, "catalog-123", products)
+	require.NoError(t, err)
+	assert.Len(t, results, 5001)
+	assert.Equal(t, []int{5000, 1}, sizes)
+}
+
+func TestClient_BatchUpsertProducts_RequestFailureMarksItems(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid catalog","code":100}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	results, err := client.BatchUpsertProducts(context.Background(), account, "catalog-123", []whatsapp.ProductInput{
+		{Name: "Product 1", Price: 1000, Currency: "USD", RetailerID: "SKU-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Error(t, results[0].Err)
+	assert.Contains(t, results[0].Err.Error(), "Invalid catalog")
+}
+
+// --- Product sets ---
+
+func TestClient_BatchDeleteProducts(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/catalog-123/batch", r.URL.Path)
+
+		var body struct {
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Requests, 2)
+		assert.Equal(t, map[string]interface{}{"method": "DELETE", "retailer_id": "SKU-1"}, body.Requests[0])
+		assert.Equal(t, "SKU-2", body.Requests[1]["retailer_id"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"handles": []string{"handle-1"},
+			"validation_status": []map[string]interface{}{
+				{"retailer_id": "SKU-2", "errors": []map[string]string{{"message": "Product not found"}}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	results, err := client.BatchDeleteProducts(context.Background(), testAccount(server.URL), "catalog-123", []string{"SKU-1", "SKU-2"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "handle-1", results[0].Handle)
+	require.Error(t, results[1].Err)
+	assert.Contains(t, results[1].Err.Error(), "Product not found")
+}
+
+func TestClient_BatchDeleteProducts_EmptyRetailerID(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid batch should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.BatchDeleteProducts(context.Background(), testAccount(server.URL), "catalog-123", []string{"SKU-1", ""})
+	require.Error(t, err)
+}
+
+func TestClient_CreateProductSet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		filter     whatsapp.ProductSetFilter
+		wantFilter string
+	}{
+		{
+			name:       "retailer IDs",
+			filter:     whatsapp.ProductSetFilter{RetailerIDs: []string{"SKU-1", "SKU-2"}},
+			wantFilter: `{"retailer_id":{"is_any":["SKU-1","SKU-2"]}}`,
+		},
+		{
+			name:       "product type",
+			filter:     whatsapp.ProductSetFilter{ProductType: "shirts"},
+			wantFilter: `{"product_type":{"i_contains":"shirts"}}`,
+		},
+		{
+			name:       "combined",
+			filter:     whatsapp.ProductSetFilter{RetailerIDs: []string{"SKU-1"}, ProductType: "shirts"},
+			wantFilter: `{"and":[{"retailer_id":{"is_any":["SKU-1"]}},{"product_type":{"i_contains":"shirts"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "/v21.0/catalog-1/product_sets", r.URL.Path)
+
+				var body map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				assert.Equal(t, "Summer", body["name"])
+				assert.JSONEq(t, tt.wantFilter, body["filter"].(string))
+
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(map[string]string{"id": "set-1"})
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server)
+			id, err := client.CreateProductSet(context.Background(), testAccount(server.URL), "catalog-1", "Summer", tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, "set-1", id)
+		})
+	}
+}
+
+func TestClient_CreateProductSet_EmptyFilter(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("empty filter should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateProductSet(context.Background(), testAccount(server.URL), "catalog-1", "Summer", whatsapp.ProductSetFilter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one condition")
+}
+
+func TestClient_ListProductSets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/catalog-1/product_sets", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "set-1", "name": "Summer", "product_count": 3}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "next-page"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "set-2", "name": "Winter"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	sets, err := client.ListProductSets(context.Background(), testAccount(server.URL), "catalog-1")
+	require.NoError(t, err)
+	require.Len(t, sets, 2)
+	assert.Equal(t, "Summer", sets[0].Name)
+	assert.Equal(t, 3, sets[0].ProductCount)
+	assert.Equal(t, "set-2", sets[1].ID)
 }
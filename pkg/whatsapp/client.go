@@ -4,10 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/zerodha/logf"
 )
@@ -17,46 +26,168 @@ const (
 	DefaultTimeout = 30 * time.Second
 	// BaseURL for Meta Graph API
 	BaseURL = "https://graph.facebook.com"
+	// DefaultMaxResponseSize is the largest Graph API response body read into memory
+	DefaultMaxResponseSize = 8 << 20
 )
 
+// Version is the whatomate version reported in the default User-Agent.
+// Release builds set it with -ldflags "-X github.com/shridarpatil/whatomate/pkg/whatsapp.Version=...".
+var Version = "dev"
+
 // Client is the WhatsApp Cloud API client
 type Client struct {
 	HTTPClient *http.Client
 	Log        logf.Logger
-	baseURL    string // For testing with mock servers
+	Retry      RetryConfig          // Retry policy for transient failures; zero value disables retries
+	Throttle   ThrottleConfig       // Proactive throttling on usage headers; zero value disables it
+	Breaker    CircuitBreakerConfig // Fail fast during Meta outages; zero value disables it
+	RequestLog RequestLogger        // Optional tracing of each Graph API request
+	Observer   Observer             // Optional metrics collection for each Graph API request
+	DryRun     bool                 // Log catalog mutations instead of sending them; reads still execute
+	// MaxResponseSize caps the bytes read from a Graph API response body;
+	// 0 uses DefaultMaxResponseSize and a negative value removes the cap.
+	// Media downloads are streamed and not limited.
+	MaxResponseSize int64
+	baseURL         string // For testing with mock servers
+	userAgent       string // Sent on every request; see WithUserAgent
+
+	rateMu     sync.Mutex
+	rateStatus RateLimitStatus
+	breaker    circuitBreaker
+	dryRunSeq  atomic.Int64 // Numbers the synthetic IDs returned in dry-run mode
 }
 
 // New creates a new WhatsApp client
-func New(log logf.Logger) *Client {
-	return &Client{
+func New(log logf.Logger, opts ...ClientOption) *Client {
+	c := &Client{
 		HTTPClient: &http.Client{
 			Timeout: DefaultTimeout,
 		},
 		Log:     log,
 		baseURL: BaseURL,
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // NewWithTimeout creates a new WhatsApp client with custom timeout
 func NewWithTimeout(log logf.Logger, timeout time.Duration) *Client {
-	return &Client{
-		HTTPClient: &http.Client{
-			Timeout: timeout,
-		},
-		Log:     log,
-		baseURL: BaseURL,
-	}
+	return New(log, WithTimeout(timeout))
 }
 
 // NewWithBaseURL creates a new WhatsApp client with a custom base URL (for testing)
 func NewWithBaseURL(log logf.Logger, baseURL string) *Client {
-	return &Client{
-		HTTPClient: &http.Client{
-			Timeout: DefaultTimeout,
-		},
-		Log:     log,
-		baseURL: baseURL,
+	return New(log, WithBaseURL(baseURL))
+}
+
+// ErrResponseTooLarge is returned when a response body exceeds Client.MaxResponseSize
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// readResponse reads a Graph API response body, failing with
+// ErrResponseTooLarge rather than buffering more than MaxResponseSize bytes
+func (c *Client) readResponse(r io.Reader) ([]byte, error) {
+	limit := c.MaxResponseSize
+	if limit == 0 {
+		limit = DefaultMaxResponseSize
+	}
+	if limit < 0 {
+		return io.ReadAll(r)
+	}
+
+	// Read one byte past the limit to tell a body of exactly limit bytes from a larger one
+	body, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: exceeds %d bytes", ErrResponseTooLarge, limit)
+	}
+	return body, nil
+}
+
+// parsePage decodes a Graph API list response, including the total count
+// reported when the request asked for summary=total_count
+func parsePage[T any](respBody []byte) (*Page[T], error) {
+	var resp struct {
+		Data    []T    `json:"data"`
+		Paging  Paging `json:"paging"`
+		Summary struct {
+			TotalCount *int `json:"total_count"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	page := &Page[T]{Data: resp.Data, NextCursor: resp.Paging.NextCursor(), TotalCount: -1}
+	if resp.Summary.TotalCount != nil {
+		page.TotalCount = *resp.Summary.TotalCount
 	}
+	return page, nil
+}
+
+// walkPages fetches the pages of a list one after another, starting with an
+// empty cursor and passing each page's NextCursor to the next fetch, and hands
+// every page to visit. It stops after the last page, when visit returns
+// false, or if Meta hands back the cursor just used.
+func walkPages[T any](fetch func(cursor string) (*Page[T], error), visit func(page *Page[T]) bool) error {
+	cursor := ""
+	for {
+		page, err := fetch(cursor)
+		if err != nil {
+			return err
+		}
+		if !visit(page) || !page.HasMore() || page.NextCursor == cursor {
+			return nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// collectPages fetches every page with walkPages and returns their items in order
+func collectPages[T any](fetch func(cursor string) (*Page[T], error)) ([]T, error) {
+	var items []T
+	err := walkPages(fetch, func(page *Page[T]) bool {
+		items = append(items, page.Data...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// listAll fetches every page of the Graph API list edge at baseURL, sending
+// params as the query of each request, and returns the items in order
+func listAll[T any](ctx context.Context, c *Client, account *Account, baseURL string, params url.Values) ([]T, error) {
+	return collectPages(func(cursor string) (*Page[T], error) {
+		query := url.Values{}
+		maps.Copy(query, params)
+		if cursor != "" {
+			query.Set("after", cursor)
+		}
+		respBody, err := c.doRequest(ctx, http.MethodGet, baseURL+"?"+query.Encode(), nil, account)
+		if err != nil {
+			return nil, err
+		}
+		return parsePage[T](respBody)
+	})
+}
+
+// newRequest creates an HTTP request carrying the client's User-Agent
+func (c *Client) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	userAgent := c.userAgent
+	if userAgent == "" {
+		userAgent = "whatomate/" + Version
+	}
+	req.Header.Set("User-Agent", userAgent)
+	return req, nil
 }
 
 // getBaseURL returns the base URL for API requests
@@ -67,52 +198,169 @@ func (c *Client) getBaseURL() string {
 	return BaseURL
 }
 
-// doRequest performs an HTTP request to the Meta API
-func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, accessToken string) ([]byte, error) {
-	var reqBody io.Reader
+// responseMeta describes the HTTP response behind a successful Meta API call,
+// e.g. to reference the trace ID when a batch reports partial failures
+type responseMeta struct {
+	StatusCode int
+	FBTraceID  string // x-fb-trace-id header, quoted by Meta support
+	Header     http.Header
+}
+
+// fbTraceIDHeader is the response header carrying Meta's trace ID
+const fbTraceIDHeader = "X-Fb-Trace-Id"
+
+// doRequest performs an HTTP request to the Meta API with the account's
+// access token, retrying transient failures according to the client's
+// RetryConfig. When the account has a TokenProvider, a request rejected for an
+// expired token is retried once with a refreshed token.
+func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, account *Account) ([]byte, error) {
+	respBody, _, err := c.doRequestWithMeta(ctx, method, url, body, account)
+	return respBody, err
+}
+
+// doRequestWithMeta is doRequest that also returns the response metadata of
+// the successful attempt
+func (c *Client) doRequestWithMeta(ctx context.Context, method, url string, body interface{}, account *Account) ([]byte, *responseMeta, error) {
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		var err error
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonBody)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, url, reqBody)
+	accessToken, err := account.accessToken(ctx, false)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+accessToken)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		respBody, meta, retryable, err := c.doRequestOnce(ctx, method, url, jsonBody, accessToken, attempt)
+		if err == nil {
+			return respBody, meta, nil
+		}
+
+		if !refreshed && account.TokenProvider != nil && isTokenExpired(err) {
+			refreshed = true
+			if accessToken, err = account.accessToken(ctx, true); err != nil {
+				return nil, nil, err
+			}
+			c.Log.Info("Retrying Meta API request with refreshed access token", "method", method)
+			attempt-- // The refresh retry does not count against the retry policy
+			continue
+		}
+
+		if !retryable || attempt >= c.Retry.MaxAttempts {
+			if attempt > 1 {
+				return nil, nil, fmt.Errorf("request failed after %d attempts: %w", attempt, err)
+			}
+			return nil, nil, err
+		}
+
+		delay := c.Retry.backoff(attempt)
+		c.Log.Warn("Retrying Meta API request", "method", method, "attempt", attempt, "delay", delay, "error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, fmt.Errorf("request canceled after %d attempts: %w", attempt, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// doRequestOnce performs a single HTTP request attempt. The returned bool
+// reports whether a failure is transient and worth retrying.
+func (c *Client) doRequestOnce(ctx context.Context, method, url string, jsonBody []byte, accessToken string, attempt int) ([]byte, *responseMeta, bool, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := c.newRequest(ctx, method, url, reqBody)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// App-authenticated calls such as the OAuth token exchange carry no user token
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
 	req.Header.Set("Content-Type", "application/json")
 
+	return c.roundTrip(req, attempt)
+}
+
+// roundTrip sends a prepared Graph API request through the rate limiter,
+// circuit breaker, RequestLogger and Observer, and returns an error response
+// as a *GraphAPIError. The returned bool reports whether a failure is
+// transient and worth retrying.
+func (c *Client) roundTrip(req *http.Request, attempt int) ([]byte, *responseMeta, bool, error) {
+	ctx := req.Context()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, false, fmt.Errorf("request canceled: %w", err)
+	}
+	if err := c.waitForRateLimit(ctx); err != nil {
+		return nil, nil, false, err
+	}
+	if err := c.allowRequest(); err != nil {
+		return nil, nil, false, err
+	}
+
+	method, rawURL := req.Method, req.URL.String()
+	c.logRequest(RequestLogEntry{Phase: RequestStarted, Method: method, URL: rawURL, Header: req.Header, Attempt: attempt})
+	start := time.Now()
+
 	resp, err := c.HTTPClient.Do(req)
+	redactURLError(err)
+
+	finished := RequestLogEntry{Phase: RequestFinished, Method: method, URL: rawURL, Header: req.Header, Attempt: attempt, Duration: time.Since(start), Err: err}
+	if resp != nil {
+		finished.Status = resp.StatusCode
+		finished.FBTraceID = resp.Header.Get(fbTraceIDHeader)
+	}
+	c.logRequest(finished)
+	c.observeRequest(method, rawURL, finished.Status, finished.Duration)
+
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		// Report cancellation as such rather than as a generic network error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			c.recordAttempt(attemptAborted)
+			return nil, nil, false, fmt.Errorf("request canceled: %w", ctxErr)
+		}
+		c.recordAttempt(attemptFailed)
+		return nil, nil, false, fmt.Errorf("request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	respBody, err := io.ReadAll(resp.Body)
+	c.updateRateLimit(resp.Header)
+
+	// Only server errors count towards the circuit breaker; a 4xx means Meta is up
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.recordAttempt(attemptFailed)
+	} else {
+		c.recordAttempt(attemptSucceeded)
+	}
+
+	respBody, err := c.readResponse(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, nil, false, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		var apiErr MetaAPIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error.Message != "" {
-			errMsg := fmt.Sprintf("API error %d: %s", apiErr.Error.Code, apiErr.Error.Message)
-			if apiErr.Error.ErrorData.Details != "" {
-				errMsg += " - Details: " + apiErr.Error.ErrorData.Details
-			}
-			if apiErr.Error.ErrorUserMsg != "" {
-				errMsg += " - " + apiErr.Error.ErrorUserMsg
-			}
-			return nil, fmt.Errorf("%s", errMsg)
+	traceID := resp.Header.Get(fbTraceIDHeader)
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		apiErr := parseGraphAPIError(resp.StatusCode, respBody)
+		if apiErr.FBTraceID == "" {
+			apiErr.FBTraceID = traceID
 		}
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+		return nil, nil, apiErr.retryableFor(req.Method), apiErr
 	}
 
-	return respBody, nil
+	meta := &responseMeta{StatusCode: resp.StatusCode, FBTraceID: traceID, Header: resp.Header}
+	return respBody, meta, false, nil
 }
 
 // CredentialsValidationResult contains the result of credentials validation
@@ -130,10 +378,12 @@ type CredentialsValidationResult struct {
 // It checks the phone number endpoint, business account endpoint, and verifies
 // that the phone number belongs to the specified business account
 func (c *Client) ValidateCredentials(ctx context.Context, phoneID, businessID, accessToken, apiVersion string) (*CredentialsValidationResult, error) {
+	creds := &Account{AccessToken: accessToken}
+
 	// 1. Validate PhoneID
 	phoneURL := fmt.Sprintf("%s/%s/%s?fields=display_phone_number,verified_name,code_verification_status,account_mode,quality_rating",
 		c.getBaseURL(), apiVersion, phoneID)
-	phoneBody, err := c.doRequest(ctx, http.MethodGet, phoneURL, nil, accessToken)
+	phoneBody, err := c.doRequest(ctx, http.MethodGet, phoneURL, nil, creds)
 	if err != nil {
 		return nil, fmt.Errorf("invalid phone_id or access_token: %w", err)
 	}
@@ -163,13 +413,13 @@ func (c *Client) ValidateCredentials(ctx context.Context, phoneID, businessID, a
 
 	// 2. Validate BusinessID
 	businessURL := fmt.Sprintf("%s/%s/%s?fields=id,name", c.getBaseURL(), apiVersion, businessID)
-	if _, err := c.doRequest(ctx, http.MethodGet, businessURL, nil, accessToken); err != nil {
+	if _, err := c.doRequest(ctx, http.MethodGet, businessURL, nil, creds); err != nil {
 		return nil, fmt.Errorf("invalid business_id: %w", err)
 	}
 
 	// 3. Verify phone belongs to business account
 	phonesURL := fmt.Sprintf("%s/%s/%s/phone_numbers", c.getBaseURL(), apiVersion, businessID)
-	phonesBody, err := c.doRequest(ctx, http.MethodGet, phonesURL, nil, accessToken)
+	phonesBody, err := c.doRequest(ctx, http.MethodGet, phonesURL, nil, creds)
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify phone-business relationship: %w", err)
 	}
@@ -226,30 +476,107 @@ type MediaURLResponse struct {
 
 // GetMediaURL retrieves the download URL for a media file from Meta's API
 func (c *Client) GetMediaURL(ctx context.Context, mediaID string, account *Account) (string, error) {
+	media, err := c.getMedia(ctx, account, mediaID)
+	if err != nil {
+		return "", err
+	}
+	return media.URL, nil
+}
+
+// MediaInfo describes a media file without its content
+type MediaInfo struct {
+	URL              string // Short-lived download URL, valid for about 5 minutes
+	MimeType         string
+	FileSizeBytes    int64
+	SHA256           string
+	MessagingProduct string
+}
+
+// GetMediaInfo retrieves a media file's metadata without downloading it, e.g.
+// to reject oversized files before calling DownloadMediaByID
+func (c *Client) GetMediaInfo(ctx context.Context, account *Account, mediaID string) (*MediaInfo, error) {
+	media, err := c.getMedia(ctx, account, mediaID)
+	if err != nil {
+		return nil, err
+	}
+	return &MediaInfo{
+		URL:              media.URL,
+		MimeType:         media.MimeType,
+		FileSizeBytes:    media.FileSize,
+		SHA256:           media.SHA256,
+		MessagingProduct: media.MessagingProduct,
+	}, nil
+}
+
+// getMedia retrieves the media object (download URL, MIME type, size) for a media ID
+func (c *Client) getMedia(ctx context.Context, account *Account, mediaID string) (*MediaURLResponse, error) {
 	url := fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, mediaID)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account)
 	if err != nil {
-		return "", fmt.Errorf("failed to get media URL: %w", err)
+		return nil, fmt.Errorf("failed to get media URL: %w", err)
 	}
 
 	var mediaResp MediaURLResponse
 	if err := json.Unmarshal(respBody, &mediaResp); err != nil {
-		return "", fmt.Errorf("failed to parse media response: %w", err)
+		return nil, fmt.Errorf("failed to parse media response: %w", err)
 	}
 
 	if mediaResp.URL == "" {
-		return "", fmt.Errorf("no URL in media response")
+		return nil, fmt.Errorf("no URL in media response")
 	}
 
-	return mediaResp.URL, nil
+	return &mediaResp, nil
 }
 
 // DownloadMedia downloads media content from Meta's CDN URL
 func (c *Client) DownloadMedia(ctx context.Context, mediaURL string, accessToken string) ([]byte, error) {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	body, _, err := c.openMedia(ctx, mediaURL, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media content: %w", err)
+	}
+
+	return data, nil
+}
+
+// DownloadMediaByID resolves a media ID and streams its content.
+// It returns the content and its MIME type; the caller must close the reader.
+func (c *Client) DownloadMediaByID(ctx context.Context, account *Account, mediaID string) (io.ReadCloser, string, error) {
+	media, err := c.getMedia(ctx, account, mediaID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	accessToken, err := account.accessToken(ctx, false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body, contentType, err := c.openMedia(ctx, media.URL, accessToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create download request: %w", err)
+		return nil, "", err
+	}
+
+	mimeType := media.MimeType
+	if mimeType == "" {
+		mimeType = contentType
+	}
+	return body, mimeType, nil
+}
+
+// openMedia starts a media download and returns the response body and its Content-Type.
+// Media URLs live on Meta's lookaside host rather than the Graph API, but still
+// require the Bearer token.
+func (c *Client) openMedia(ctx context.Context, mediaURL, accessToken string) (io.ReadCloser, string, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create download request: %w", err)
 	}
 
 	// Meta requires Bearer token for media download
@@ -257,20 +584,15 @@ func (c *Client) DownloadMedia(ctx context.Context, mediaURL string, accessToken
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to download media: %w", err)
+		return nil, "", fmt.Errorf("failed to download media: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("media download failed with status %d", resp.StatusCode)
-	}
-
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read media content: %w", err)
+		_ = resp.Body.Close()
+		return nil, "", fmt.Errorf("media download failed with status %d", resp.StatusCode)
 	}
 
-	return data, nil
+	return resp.Body, resp.Header.Get("Content-Type"), nil
 }
 
 // UploadMediaResponse represents the response from uploading media
@@ -280,47 +602,44 @@ type UploadMediaResponse struct {
 
 // UploadMedia uploads media to WhatsApp's servers and returns the media ID
 func (c *Client) UploadMedia(ctx context.Context, account *Account, data []byte, mimeType, filename string) (string, error) {
-	url := fmt.Sprintf("%s/%s/%s/media", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	return c.UploadMediaStream(ctx, account, bytes.NewReader(data), mimeType, filename)
+}
 
-	// Create multipart form body
-	body := &bytes.Buffer{}
-	boundary := "----WebKitFormBoundary7MA4YWxkTrZu0gW"
+// quoteEscaper escapes filenames for the multipart Content-Disposition header
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
 
-	// Build multipart body manually
-	fmt.Fprintf(body, "--%s\r\n", boundary)
-	body.WriteString("Content-Disposition: form-data; name=\"messaging_product\"\r\n\r\n")
-	body.WriteString("whatsapp\r\n")
+// UploadMediaStream uploads media read from r and returns the media ID.
+// The multipart body is streamed to Meta, so large files are never held in
+// memory; for the same reason a failed upload is not retried.
+func (c *Client) UploadMediaStream(ctx context.Context, account *Account, r io.Reader, mimeType, filename string) (string, error) {
+	url := fmt.Sprintf("%s/%s/%s/media", c.getBaseURL(), account.APIVersion, account.PhoneID)
 
-	fmt.Fprintf(body, "--%s\r\n", boundary)
-	fmt.Fprintf(body, "Content-Disposition: form-data; name=\"file\"; filename=\"%s\"\r\n", filename)
-	fmt.Fprintf(body, "Content-Type: %s\r\n\r\n", mimeType)
-	body.Write(data)
-	body.WriteString("\r\n")
+	accessToken, err := account.accessToken(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	pr, pw := io.Pipe()
+	defer func() { _ = pr.Close() }()
+	mw := multipart.NewWriter(pw)
 
-	fmt.Fprintf(body, "--%s--\r\n", boundary)
+	// Write the multipart body as the request consumes it
+	go func() {
+		pw.CloseWithError(writeMediaForm(mw, r, mimeType, filename))
+	}()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	req, err := c.newRequest(ctx, http.MethodPost, url, pr)
 	if err != nil {
 		return "", fmt.Errorf("failed to create upload request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+account.AccessToken)
-	req.Header.Set("Content-Type", fmt.Sprintf("multipart/form-data; boundary=%s", boundary))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
 
-	resp, err := c.HTTPClient.Do(req)
+	respBody, _, _, err := c.roundTrip(req, 1)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload media: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read upload response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("media upload failed with status %d: %s", resp.StatusCode, string(respBody))
-	}
 
 	var uploadResp UploadMediaResponse
 	if err := json.Unmarshal(respBody, &uploadResp); err != nil {
@@ -335,162 +654,100 @@ func (c *Client) UploadMedia(ctx context.Context, account *Account, data []byte,
 	return uploadResp.ID, nil
 }
 
-// SendImageMessage sends an image message using a media ID
-func (c *Client) SendImageMessage(ctx context.Context, account *Account, phoneNumber, mediaID, caption string) (string, error) {
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "image",
-		"image": map[string]interface{}{
-			"id":      mediaID,
-			"caption": caption,
-		},
+// writeMediaForm writes the media upload form fields followed by the file content
+func writeMediaForm(mw *multipart.Writer, r io.Reader, mimeType, filename string) error {
+	if err := mw.WriteField("messaging_product", "whatsapp"); err != nil {
+		return err
+	}
+	if err := mw.WriteField("type", mimeType); err != nil {
+		return err
 	}
 
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending image message", "phone", phoneNumber, "media_id", mediaID)
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", mimeType)
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
+	part, err := mw.CreatePart(header)
 	if err != nil {
-		return "", fmt.Errorf("failed to send image message: %w", err)
+		return err
 	}
-
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	if _, err := io.Copy(part, r); err != nil {
+		return fmt.Errorf("failed to read media content: %w", err)
 	}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
-	}
+	return mw.Close()
+}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Image message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+// SendImageMessage sends an image message using a media ID
+func (c *Client) SendImageMessage(ctx context.Context, account *Account, phoneNumber, mediaID, caption string) (string, error) {
+	return c.SendImage(ctx, account, phoneNumber, MediaRef{ID: mediaID}, caption)
 }
 
 // SendDocumentMessage sends a document message using a media ID
 func (c *Client) SendDocumentMessage(ctx context.Context, account *Account, phoneNumber, mediaID, filename, caption string) (string, error) {
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "document",
-		"document": map[string]interface{}{
-			"id":       mediaID,
-			"filename": filename,
-			"caption":  caption,
-		},
-	}
-
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending document message", "phone", phoneNumber, "media_id", mediaID)
-
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		return "", fmt.Errorf("failed to send document message: %w", err)
-	}
-
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
-	}
-
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Document message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+	return c.SendDocument(ctx, account, phoneNumber, DocumentMessage{MediaID: mediaID, Filename: filename, Caption: caption})
 }
 
 // SendVideoMessage sends a video message using a media ID
 func (c *Client) SendVideoMessage(ctx context.Context, account *Account, phoneNumber, mediaID, caption string) (string, error) {
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "video",
-		"video": map[string]interface{}{
-			"id":      mediaID,
-			"caption": caption,
-		},
-	}
-
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending video message", "phone", phoneNumber, "media_id", mediaID)
-
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		return "", fmt.Errorf("failed to send video message: %w", err)
-	}
-
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
-	}
-
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Video message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+	return c.SendVideo(ctx, account, phoneNumber, MediaRef{ID: mediaID}, caption)
 }
 
 // SendAudioMessage sends an audio message using a media ID
 func (c *Client) SendAudioMessage(ctx context.Context, account *Account, phoneNumber, mediaID string) (string, error) {
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "audio",
-		"audio": map[string]interface{}{
-			"id": mediaID,
-		},
-	}
-
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending audio message", "phone", phoneNumber, "media_id", mediaID)
+	return c.SendAudio(ctx, account, phoneNumber, MediaRef{ID: mediaID})
+}
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		return "", fmt.Errorf("failed to send audio message: %w", err)
-	}
+// MarkMessageRead sends a read receipt for an inbound message.
+// Meta rejects messages older than 30 days; the returned error then wraps the
+// *GraphAPIError describing the rejection.
+func (c *Client) MarkMessageRead(ctx context.Context, account *Account, messageID string) error {
+	return c.markRead(ctx, account, messageID, false)
+}
 
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
+// SendTypingIndicator marks an inbound message as read and shows the customer
+// a typing indicator while a reply is prepared. The indicator disappears when
+// the reply is sent or after 25 seconds. As with MarkMessageRead, messages
+// older than 30 days are rejected with a *GraphAPIError.
+func (c *Client) SendTypingIndicator(ctx context.Context, account *Account, messageID string) error {
+	return c.markRead(ctx, account, messageID, true)
+}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+// markRead sends a read receipt, optionally with a typing indicator
+func (c *Client) markRead(ctx context.Context, account *Account, messageID string, typing bool) error {
+	if messageID == "" {
+		return fmt.Errorf("message ID is required")
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Audio message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
-}
-
-// MarkMessageRead sends a read receipt for a message
-func (c *Client) MarkMessageRead(ctx context.Context, account *Account, messageID string) error {
 	payload := map[string]interface{}{
 		"messaging_product": "whatsapp",
 		"status":            "read",
 		"message_id":        messageID,
 	}
+	if typing {
+		payload["typing_indicator"] = map[string]interface{}{
+			"type": "text",
+		}
+	}
 
 	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending read receipt", "message_id", messageID)
+	c.Log.Debug("Sending read receipt", "message_id", messageID, "typing", typing)
 
-	_, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
+	respBody, err := c.doRequest(ctx, "POST", url, payload, account)
 	if err != nil {
 		return fmt.Errorf("failed to send read receipt: %w", err)
 	}
 
+	var resp struct {
+		Success bool `json:"success"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("read receipt not accepted for message %s", messageID)
+	}
+
 	c.Log.Debug("Read receipt sent", "message_id", messageID)
 	return nil
 }
@@ -524,7 +781,7 @@ func (c *Client) ResumableUpload(ctx context.Context, account *Account, data []b
 
 	c.Log.Info("Creating upload session", "url", sessionURL, "file_size", len(data), "mime_type", mimeType)
 
-	sessionResp, err := c.doRequest(ctx, http.MethodPost, sessionURL, sessionPayload, account.AccessToken)
+	sessionResp, err := c.doRequest(ctx, http.MethodPost, sessionURL, sessionPayload, account)
 	if err != nil {
 		return "", fmt.Errorf("failed to create upload session: %w", err)
 	}
@@ -543,29 +800,24 @@ func (c *Client) ResumableUpload(ctx context.Context, account *Account, data []b
 	// Step 2: Upload file data to session
 	uploadURL := fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, uploadSession.ID)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(data))
+	accessToken, err := account.accessToken(ctx, false)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := c.newRequest(ctx, http.MethodPost, uploadURL, bytes.NewReader(data))
 	if err != nil {
 		return "", fmt.Errorf("failed to create upload request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "OAuth "+account.AccessToken)
+	req.Header.Set("Authorization", "OAuth "+accessToken)
 	req.Header.Set("file_offset", "0")
 	req.Header.Set("Content-Type", "application/octet-stream")
 
-	resp, err := c.HTTPClient.Do(req)
+	respBody, _, _, err := c.roundTrip(req, 1)
 	if err != nil {
 		return "", fmt.Errorf("failed to upload file data: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read upload response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("upload failed with status %d: %s", resp.StatusCode, string(respBody))
-	}
 
 	var finishResp ResumableUploadFinishResponse
 	if err := json.Unmarshal(respBody, &finishResp); err != nil {
@@ -576,7 +828,11 @@ func (c *Client) ResumableUpload(ctx context.Context, account *Account, data []b
 		return "", fmt.Errorf("no handle in upload response")
 	}
 
-	c.Log.Info("Resumable upload completed", "handle", finishResp.Handle[:20]+"...")
+	logged := finishResp.Handle
+	if len(logged) > 20 {
+		logged = logged[:20] + "..."
+	}
+	c.Log.Info("Resumable upload completed", "handle", logged)
 	return finishResp.Handle, nil
 }
 
@@ -591,7 +847,7 @@ func (c *Client) GetBusinessProfile(ctx context.Context, account *Account) (*Bus
 	fields := "about,address,description,email,profile_picture_url,websites,vertical,messaging_product"
 	url := fmt.Sprintf("%s/%s/%s/whatsapp_business_profile?fields=%s", c.getBaseURL(), account.APIVersion, account.PhoneID, fields)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get business profile: %w", err)
 	}
@@ -608,14 +864,51 @@ func (c *Client) GetBusinessProfile(ctx context.Context, account *Account) (*Bus
 	return &response.Data[0], nil
 }
 
-// UpdateBusinessProfile updates the business profile settings
+// Business profile limits enforced by Meta
+const (
+	maxProfileAbout       = 139
+	maxProfileAddress     = 256
+	maxProfileDescription = 512
+	maxProfileEmail       = 128
+	maxProfileWebsites    = 2
+)
+
+// validate checks the business profile input against Meta's field limits
+func (in *BusinessProfileInput) validate() error {
+	limits := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"about", in.About, maxProfileAbout},
+		{"address", in.Address, maxProfileAddress},
+		{"description", in.Description, maxProfileDescription},
+		{"email", in.Email, maxProfileEmail},
+	}
+	for _, l := range limits {
+		if utf8.RuneCountInString(l.value) > l.max {
+			return fmt.Errorf("%s exceeds %d characters", l.field, l.max)
+		}
+	}
+	if len(in.Websites) > maxProfileWebsites {
+		return fmt.Errorf("maximum %d websites allowed", maxProfileWebsites)
+	}
+	return nil
+}
+
+// UpdateBusinessProfile updates the business profile settings.
+// Empty fields are omitted so they are left unchanged.
 func (c *Client) UpdateBusinessProfile(ctx context.Context, account *Account, input BusinessProfileInput) error {
+	if err := input.validate(); err != nil {
+		return err
+	}
+
 	url := fmt.Sprintf("%s/%s/%s/whatsapp_business_profile", c.getBaseURL(), account.APIVersion, account.PhoneID)
 
 	// Ensure messaging_product is set
 	input.MessagingProduct = "whatsapp"
 
-	_, err := c.doRequest(ctx, http.MethodPost, url, input, account.AccessToken)
+	_, err := c.doRequest(ctx, http.MethodPost, url, input, account)
 	if err != nil {
 		return fmt.Errorf("failed to update business profile: %w", err)
 	}
@@ -634,7 +927,7 @@ type SubscribeAppResponse struct {
 func (c *Client) SubscribeApp(ctx context.Context, account *Account) error {
 	url := fmt.Sprintf("%s/%s/%s/subscribed_apps", c.getBaseURL(), account.APIVersion, account.BusinessID)
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account)
 	if err != nil {
 		return fmt.Errorf("failed to subscribe app to webhooks: %w", err)
 	}
@@ -651,3 +944,59 @@ func (c *Client) SubscribeApp(ctx context.Context, account *Account) error {
 	c.Log.Info("App subscribed to webhooks", "business_id", account.BusinessID)
 	return nil
 }
+
+// SubscribedApp is an app receiving webhooks for a WhatsApp Business Account
+type SubscribedApp struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Link string `json:"link,omitempty"`
+}
+
+// ListSubscribedApps returns the apps subscribed to the account's WABA webhooks.
+// Calls GET /{api_version}/{waba_id}/subscribed_apps
+func (c *Client) ListSubscribedApps(ctx context.Context, account *Account) ([]SubscribedApp, error) {
+	url := fmt.Sprintf("%s/%s/%s/subscribed_apps", c.getBaseURL(), account.APIVersion, account.BusinessID)
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscribed apps: %w", err)
+	}
+
+	var resp struct {
+		Data []struct {
+			App SubscribedApp `json:"whatsapp_business_api_data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse subscribed apps response: %w", err)
+	}
+
+	apps := make([]SubscribedApp, 0, len(resp.Data))
+	for _, entry := range resp.Data {
+		apps = append(apps, entry.App)
+	}
+	return apps, nil
+}
+
+// UnsubscribeApp stops webhook delivery to the app for the WhatsApp Business Account.
+// Calls DELETE /{api_version}/{waba_id}/subscribed_apps
+func (c *Client) UnsubscribeApp(ctx context.Context, account *Account) error {
+	url := fmt.Sprintf("%s/%s/%s/subscribed_apps", c.getBaseURL(), account.APIVersion, account.BusinessID)
+
+	respBody, err := c.doRequest(ctx, http.MethodDelete, url, nil, account)
+	if err != nil {
+		return fmt.Errorf("failed to unsubscribe app from webhooks: %w", err)
+	}
+
+	var resp SubscribeAppResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to parse unsubscribe response: %w", err)
+	}
+
+	if !resp.Success {
+		return fmt.Errorf("unsubscribe was not successful")
+	}
+
+	c.Log.Info("App unsubscribed from webhooks", "business_id", account.BusinessID)
+	return nil
+}
@@ -1,10 +1,16 @@
 package whatsapp_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/shridarpatil/whatomate/pkg/whatsapp"
@@ -70,7 +76,7 @@ func TestClient_SendTextMessage(t *testing.T) {
 		},
 		{
 			name:  "API error - invalid phone",
-			phone: "invalid",
+			phone: "0000",
 			text:  "Hello",
 			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
 				w.WriteHeader(http.StatusBadRequest)
@@ -94,6 +100,16 @@ func TestClient_SendTextMessage(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "Invalid phone number format",
 		},
+		{
+			name:  "text too long",
+			phone: "1234567890",
+			text:  strings.Repeat("é", 4097),
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				t.Error("oversized text should not reach the API")
+			},
+			wantErr:         true,
+			wantErrContains: "text body is 4097 characters, exceeds maximum of 4096",
+		},
 		{
 			name:  "API error - unauthorized",
 			phone: "1234567890",
@@ -133,6 +149,48 @@ func TestClient_SendTextMessage(t *testing.T) {
 			wantErr:         true,
 			wantErrContains: "no message ID",
 		},
+		{
+			name:  "plus-prefixed phone accepted",
+			phone: "+1234567890",
+			text:  "Hello",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"messages": []map[string]string{{"id": "wamid.plus"}},
+				})
+			},
+			wantMessageID: "wamid.plus",
+		},
+		{
+			name:  "non-digit phone rejected locally",
+			phone: "123-456-7890",
+			text:  "Hello",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				t.Error("request should not be sent")
+			},
+			wantErr:         true,
+			wantErrContains: "digits only",
+		},
+		{
+			name:  "empty phone rejected locally",
+			phone: "",
+			text:  "Hello",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				t.Error("request should not be sent")
+			},
+			wantErr:         true,
+			wantErrContains: "phone number is required",
+		},
+		{
+			name:  "too long phone rejected locally",
+			phone: "1234567890123456",
+			text:  "Hello",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				t.Error("request should not be sent")
+			},
+			wantErr:         true,
+			wantErrContains: "exceeds 15 digits",
+		},
 	}
 
 	for _, tt := range tests {
@@ -173,6 +231,24 @@ func TestClient_SendTextMessage(t *testing.T) {
 	}
 }
 
+func TestClient_SendTextMessage_PreviewURL(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.preview", &body)
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	_, err := client.SendTextMessage(testutil.TestContext(t), account, "1234567890", "Track it at https://example.com/42")
+	require.NoError(t, err)
+	assert.Equal(t, false, body["text"].(map[string]interface{})["preview_url"])
+
+	_, err = client.SendTextMessage(testutil.TestContext(t), account, "1234567890", "Track it at https://example.com/42",
+		whatsapp.WithPreviewURL())
+	require.NoError(t, err)
+	assert.Equal(t, true, body["text"].(map[string]interface{})["preview_url"])
+}
+
 func TestClient_GetMediaURL(t *testing.T) {
 	t.Parallel()
 
@@ -330,6 +406,218 @@ func TestClient_DownloadMedia(t *testing.T) {
 	}
 }
 
+func TestClient_GetMediaInfo(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/media-123", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"url":"https://lookaside.fbsbx.com/media-123","mime_type":"video/mp4","sha256":"abc123","file_size":52428800,"messaging_product":"whatsapp"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	info, err := client.GetMediaInfo(context.Background(), testAccount(server.URL), "media-123")
+	require.NoError(t, err)
+	assert.Equal(t, &whatsapp.MediaInfo{
+		URL:              "https://lookaside.fbsbx.com/media-123",
+		MimeType:         "video/mp4",
+		FileSizeBytes:    52428800,
+		SHA256:           "abc123",
+		MessagingProduct: "whatsapp",
+	}, info)
+}
+
+func TestClient_DownloadMediaByID(t *testing.T) {
+	t.Parallel()
+
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-access-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/v21.0/media-123":
+			_ = json.NewEncoder(w).Encode(whatsapp.MediaURLResponse{
+				URL:      serverURL + "/lookaside/media-123",
+				MimeType: "image/jpeg",
+			})
+		case "/lookaside/media-123":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = w.Write([]byte("fake image data"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := whatsapp.NewWithBaseURL(testutil.NopLogger(), server.URL)
+	body, mimeType, err := client.DownloadMediaByID(testutil.TestContext(t), testAccount(server.URL), "media-123")
+	require.NoError(t, err)
+	defer func() { _ = body.Close() }()
+
+	assert.Equal(t, "image/jpeg", mimeType)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	assert.Equal(t, "fake image data", string(data))
+}
+
+func TestClient_DownloadMediaByID_DownloadFailed(t *testing.T) {
+	t.Parallel()
+
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v21.0/media-123" {
+			_ = json.NewEncoder(w).Encode(whatsapp.MediaURLResponse{URL: serverURL + "/lookaside/media-123"})
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := whatsapp.NewWithBaseURL(testutil.NopLogger(), server.URL)
+	_, _, err := client.DownloadMediaByID(testutil.TestContext(t), testAccount(server.URL), "media-123")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 403")
+}
+
+func TestClient_MarkMessageRead_MessageTooOld(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"(#100) Invalid parameter","code":100,"error_data":{"details":"Message is too old to be marked as read"}}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.MarkMessageRead(testutil.TestContext(t), testAccount(server.URL), "wamid.old")
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 100, apiErr.Code)
+	assert.Contains(t, apiErr.Details, "too old")
+}
+
+func TestClient_SendTypingIndicator(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/123456789/messages", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "read", body["status"])
+		assert.Equal(t, "wamid.inbound", body["message_id"])
+		assert.Equal(t, map[string]interface{}{"type": "text"}, body["typing_indicator"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.SendTypingIndicator(testutil.TestContext(t), testAccount(server.URL), "wamid.inbound"))
+}
+
+func TestClient_UploadMediaStream(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789/media", r.URL.Path)
+		assert.Equal(t, "Bearer test-access-token", r.Header.Get("Authorization"))
+
+		require.NoError(t, r.ParseMultipartForm(1<<20))
+		assert.Equal(t, "whatsapp", r.FormValue("messaging_product"))
+
+		file, header, err := r.FormFile("file")
+		require.NoError(t, err)
+		defer func() { _ = file.Close() }()
+		assert.Equal(t, "photo.jpg", header.Filename)
+		assert.Equal(t, "image/jpeg", header.Header.Get("Content-Type"))
+
+		data, _ := io.ReadAll(file)
+		assert.Equal(t, "fake image data", string(data))
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(whatsapp.UploadMediaResponse{ID: "media-456"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	mediaID, err := client.UploadMediaStream(testutil.TestContext(t), testAccount(server.URL), strings.NewReader("fake image data"), "image/jpeg", "photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "media-456", mediaID)
+}
+
+func TestClient_UploadMediaStream_APIError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"(#131053) Media upload error","type":"OAuthException","code":131053,"error_data":{"details":"Unsupported file type"},"fbtrace_id":"AbCdEf"}}`))
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithObserver(observer))
+	_, err := client.UploadMediaStream(testutil.TestContext(t), testAccount(server.URL), strings.NewReader("fake image data"), "image/jpeg", "photo.jpg")
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 131053, apiErr.Code)
+	assert.Equal(t, "Unsupported file type", apiErr.Details)
+	assert.Equal(t, []observation{{http.MethodPost, "media", http.StatusBadRequest}}, observer.observations)
+}
+
+func TestClient_UploadMediaStream_CircuitOpen(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL),
+		whatsapp.WithCircuitBreaker(whatsapp.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour}))
+	ctx := testutil.TestContext(t)
+	account := testAccount(server.URL)
+
+	_, err := client.UploadMediaStream(ctx, account, strings.NewReader("fake image data"), "image/jpeg", "photo.jpg")
+	require.Error(t, err)
+	assert.Equal(t, whatsapp.CircuitOpen, client.CircuitState())
+
+	_, err = client.UploadMediaStream(ctx, account, strings.NewReader("fake image data"), "image/jpeg", "photo.jpg")
+	require.ErrorIs(t, err, whatsapp.ErrCircuitOpen)
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestClient_UploadMediaStream_ReaderError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(whatsapp.UploadMediaResponse{ID: "media-456"})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	reader := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("disk failure")))
+	_, err := client.UploadMediaStream(testutil.TestContext(t), testAccount(server.URL), reader, "image/jpeg", "photo.jpg")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk failure")
+}
+
 func TestClient_MarkMessageRead(t *testing.T) {
 	t.Parallel()
 
@@ -363,6 +651,23 @@ func TestClient_MarkMessageRead(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:      "success false",
+			messageID: "wamid.test123",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_ = json.NewEncoder(w).Encode(map[string]bool{"success": false})
+			},
+			wantErr: true,
+		},
+		{
+			name:      "empty message ID",
+			messageID: "",
+			serverResponse: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+				t.Error("empty message ID should not reach the API")
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -476,3 +781,163 @@ func (t *testServerTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	testReq.URL.Host = t.serverURL[7:] // Remove "http://"
 	return http.DefaultTransport.RoundTrip(testReq)
 }
+
+func TestClient_DoRequest_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	var called atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called.Store(true)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	_, err := client.ListCatalogs(ctx, testAccount(server.URL))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "request canceled")
+	assert.Less(t, time.Since(start), time.Second)
+	assert.False(t, called.Load())
+}
+
+func TestClient_DoRequest_DeadlineWhileWaiting(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	// No client timeout, so only the context can end the request
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithTimeout(0), whatsapp.WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ListCatalogs(ctx, testAccount(server.URL))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "request canceled")
+}
+
+func TestClient_GetBusinessProfile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/123456789/whatsapp_business_profile", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("fields"), "about")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{
+				"about":    "Always open",
+				"email":    "hello@acme.test",
+				"websites": []string{"https://acme.test"},
+				"vertical": "RETAIL",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	profile, err := client.GetBusinessProfile(testutil.TestContext(t), testAccount(server.URL))
+	require.NoError(t, err)
+	assert.Equal(t, "Always open", profile.About)
+	assert.Equal(t, "RETAIL", profile.Vertical)
+	assert.Equal(t, []string{"https://acme.test"}, profile.Websites)
+}
+
+func TestClient_UpdateBusinessProfile_OnlySendsSetFields(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, map[string]interface{}{
+			"messaging_product": "whatsapp",
+			"about":             "Back soon",
+		}, body)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]bool{"success": true})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.UpdateBusinessProfile(testutil.TestContext(t), testAccount(server.URL), whatsapp.BusinessProfileInput{About: "Back soon"})
+	require.NoError(t, err)
+}
+
+func TestClient_UpdateBusinessProfile_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	ctx := testutil.TestContext(t)
+
+	err := client.UpdateBusinessProfile(ctx, testAccount(""), whatsapp.BusinessProfileInput{About: strings.Repeat("a", 140)})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "about exceeds")
+
+	err = client.UpdateBusinessProfile(ctx, testAccount(""), whatsapp.BusinessProfileInput{Websites: []string{"a", "b", "c"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "websites")
+}
+
+func TestClient_SubscribedApps(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/987654321/subscribed_apps", r.URL.Path)
+		methods = append(methods, r.Method)
+
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"data":[{"whatsapp_business_api_data":{"id":"app-1","name":"Whatomate","link":"https://www.facebook.com/games/?app_id=app-1"}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+	ctx := testutil.TestContext(t)
+
+	require.NoError(t, client.SubscribeApp(ctx, account))
+
+	apps, err := client.ListSubscribedApps(ctx, account)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "app-1", apps[0].ID)
+	assert.Equal(t, "Whatomate", apps[0].Name)
+
+	require.NoError(t, client.UnsubscribeApp(ctx, account))
+	assert.Equal(t, []string{http.MethodPost, http.MethodGet, http.MethodDelete}, methods)
+}
+
+func TestClient_UnsubscribeApp_NotSuccessful(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":false}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.UnsubscribeApp(testutil.TestContext(t), testAccount(server.URL))
+	require.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package whatsapp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code such as "USD". Values from external
+// data can be converted with ParseCurrency or checked with Valid.
+type Currency string
+
+// Commonly used currencies; any other ISO 4217 code is valid as well
+const (
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+	CurrencyGBP Currency = "GBP"
+	CurrencyINR Currency = "INR"
+	CurrencyBRL Currency = "BRL"
+	CurrencyIDR Currency = "IDR"
+	CurrencyMXN Currency = "MXN"
+	CurrencyAED Currency = "AED"
+	CurrencySAR Currency = "SAR"
+	CurrencyJPY Currency = "JPY"
+)
+
+// ParseCurrency converts a currency code from external data, in any case, into a Currency
+func ParseCurrency(code string) (Currency, error) {
+	currency := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currency.Valid() {
+		return "", fmt.Errorf("invalid currency %q: must be an ISO 4217 code such as USD", code)
+	}
+	return currency, nil
+}
+
+// Valid reports whether c is an active ISO 4217 currency code
+func (c Currency) Valid() bool {
+	return isoCurrencies[strings.ToUpper(string(c))]
+}
+
+// Exponent returns the number of minor-unit decimal places of the currency,
+// as CurrencyExponent does
+func (c Currency) Exponent() int {
+	return CurrencyExponent(string(c))
+}
+
+// currencyExponents lists ISO 4217 currencies whose minor unit is not 2 decimal places
+var currencyExponents = map[string]int{
+	// Zero-decimal currencies
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	// Three-decimal currencies
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	// Four-decimal currencies
+	"CLF": 4, "UYW": 4,
+}
+
+// isoCurrencies is the set of active ISO 4217 currency codes
+var isoCurrencies = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true, "ARS": true, "AUD": true, "AWG": true, "AZN": true,
+	"BAM": true, "BBD": true, "BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true, "BOB": true, "BOV": true,
+	"BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true, "BZD": true, "CAD": true, "CDF": true, "CHE": true, "CHF": true,
+	"CHW": true, "CLF": true, "CLP": true, "CNY": true, "COP": true, "COU": true, "CRC": true, "CUC": true, "CUP": true, "CVE": true,
+	"CZK": true, "DJF": true, "DKK": true, "DOP": true, "DZD": true, "EGP": true, "ERN": true, "ETB": true, "EUR": true, "FJD": true,
+	"FKP": true, "GBP": true, "GEL": true, "GHS": true, "GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true,
+	"HNL": true, "HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true, "IQD": true, "IRR": true, "ISK": true, "JMD": true,
+	"JOD": true, "JPY": true, "KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true, "KWD": true, "KYD": true,
+	"KZT": true, "LAK": true, "LBP": true, "LKR": true, "LRD": true, "LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true,
+	"MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true, "MVR": true, "MWK": true, "MXN": true, "MXV": true,
+	"MYR": true, "MZN": true, "NAD": true, "NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true, "PAB": true,
+	"PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true, "PYG": true, "QAR": true, "RON": true, "RSD": true, "RUB": true,
+	"RWF": true, "SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true, "SHP": true, "SLE": true, "SLL": true,
+	"SOS": true, "SRD": true, "SSP": true, "STN": true, "SVC": true, "SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true,
+	"TND": true, "TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true, "UAH": true, "UGX": true, "USD": true, "USN": true,
+	"UYI": true, "UYU": true, "UYW": true, "UZS": true, "VED": true, "VES": true, "VND": true, "VUV": true, "WST": true, "XAF": true,
+	"XCD": true, "XOF": true, "XPF": true, "YER": true, "ZAR": true, "ZMW": true, "ZWL": true,
+}
+
+// validateCurrency checks that currency is an ISO 4217 code such as "USD"
+func validateCurrency(currency Currency) error {
+	if !currency.Valid() {
+		return fmt.Errorf("invalid currency %q: must be an ISO 4217 code such as USD", currency)
+	}
+	return nil
+}
+
+// CurrencyExponent returns the number of minor-unit decimal places for a currency.
+// Unknown currencies default to 2 (e.g. cents).
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// FormatPrice converts a price in minor units into the decimal string Meta expects,
+// e.g. 1299 USD -> "12.99", 1299 JPY -> "1299", 1299 BHD -> "1.299"
+func FormatPrice(minorUnits int64, currency string) string {
+	exp := CurrencyExponent(currency)
+	if exp == 0 {
+		return strconv.FormatInt(minorUnits, 10)
+	}
+
+	sign := ""
+	if minorUnits < 0 {
+		sign = "-"
+		minorUnits = -minorUnits
+	}
+
+	divisor := int64(1)
+	for i := 0; i < exp; i++ {
+		divisor *= 10
+	}
+
+	return fmt.Sprintf("%s%d.%0*d", sign, minorUnits/divisor, exp, minorUnits%divisor)
+}
+
+// plainPrice strips the display formatting Meta reports product prices with,
+// e.g. "$1,299.00" -> "1299.00", leaving a decimal ParsePrice accepts
+func plainPrice(display string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' {
+			return r
+		}
+		return -1
+	}, display)
+}
+
+// ParsePrice converts a decimal price string into minor units, the inverse of
+// FormatPrice, e.g. "12.99" USD -> 1299, "1299" JPY -> 1299, "1.5" BHD -> 1500.
+// It fails if price has more decimal places than the currency allows.
+func ParsePrice(price, currency string) (int64, error) {
+	exp := CurrencyExponent(currency)
+
+	s := strings.TrimSpace(price)
+	negative := strings.HasPrefix(s, "-")
+	whole, frac, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	if whole == "" || strings.Trim(whole+frac, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid price %q", price)
+	}
+	if len(frac) > exp {
+		return 0, fmt.Errorf("invalid price %q: %s allows %d decimal places", price, strings.ToUpper(currency), exp)
+	}
+
+	minorUnits, err := strconv.ParseInt(whole+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid price %q: %w", price, err)
+	}
+	if negative {
+		minorUnits = -minorUnits
+	}
+	return minorUnits, nil
+}
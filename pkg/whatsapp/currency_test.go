@@ -0,0 +1,86 @@
+package whatsapp_test
+
+import (
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		minorUnits int64
+		currency   string
+		want       string
+	}{
+		{name: "USD two decimals", minorUnits: 1299, currency: "USD", want: "12.99"},
+		{name: "USD pads cents", minorUnits: 1205, currency: "USD", want: "12.05"},
+		{name: "USD below one unit", minorUnits: 5, currency: "USD", want: "0.05"},
+		{name: "JPY zero decimals", minorUnits: 1299, currency: "JPY", want: "1299"},
+		{name: "BHD three decimals", minorUnits: 1299, currency: "BHD", want: "1.299"},
+		{name: "lowercase currency", minorUnits: 1299, currency: "bhd", want: "1.299"},
+		{name: "unknown currency defaults to two", minorUnits: 1299, currency: "", want: "12.99"},
+		{name: "negative amount", minorUnits: -1299, currency: "USD", want: "-12.99"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, whatsapp.FormatPrice(tt.minorUnits, tt.currency))
+		})
+	}
+}
+
+func TestParsePrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		price    string
+		currency string
+		want     int64
+		wantErr  bool
+	}{
+		{name: "USD two decimals", price: "12.99", currency: "USD", want: 1299},
+		{name: "USD one decimal", price: "12.9", currency: "USD", want: 1290},
+		{name: "USD whole units", price: "12", currency: "USD", want: 1200},
+		{name: "JPY zero decimals", price: "1299", currency: "JPY", want: 1299},
+		{name: "BHD three decimals", price: "1.299", currency: "bhd", want: 1299},
+		{name: "surrounding spaces", price: " 0.05 ", currency: "USD", want: 5},
+		{name: "negative amount", price: "-12.99", currency: "USD", want: -1299},
+		{name: "too many decimals", price: "12.999", currency: "USD", wantErr: true},
+		{name: "decimals on zero-decimal currency", price: "12.5", currency: "JPY", wantErr: true},
+		{name: "not a number", price: "12,99", currency: "USD", wantErr: true},
+		{name: "empty", price: "", currency: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := whatsapp.ParsePrice(tt.price, tt.currency)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCurrency(t *testing.T) {
+	t.Parallel()
+
+	currency, err := whatsapp.ParseCurrency(" usd ")
+	assert.NoError(t, err)
+	assert.Equal(t, whatsapp.CurrencyUSD, currency)
+	assert.True(t, currency.Valid())
+	assert.Equal(t, 0, whatsapp.CurrencyJPY.Exponent())
+
+	_, err = whatsapp.ParseCurrency("EURO")
+	assert.Error(t, err)
+	assert.False(t, whatsapp.Currency("XYZ").Valid())
+}
@@ -0,0 +1,38 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// doCatalogWrite sends a catalog mutation. In dry-run mode the request is
+// logged instead of sent and a synthetic response is returned whose "id",
+// "success" and "handles" fields satisfy every catalog write endpoint.
+func (c *Client) doCatalogWrite(ctx context.Context, method, url string, body interface{}, account *Account) ([]byte, *responseMeta, error) {
+	if !c.DryRun {
+		return c.doRequestWithMeta(ctx, method, url, body, account)
+	}
+
+	var jsonBody []byte
+	if body != nil {
+		var err error
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+	}
+
+	id := fmt.Sprintf("dry-run-%d", c.dryRunSeq.Add(1))
+	c.Log.Info("Dry run: skipping Meta API request", "method", method, "url", redactURL(url), "body", string(jsonBody), "synthetic_id", id)
+
+	resp, err := json.Marshal(map[string]interface{}{
+		"id":      id,
+		"success": true,
+		"handles": []string{id},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build dry-run response: %w", err)
+	}
+	return resp, &responseMeta{StatusCode: 200}, nil
+}
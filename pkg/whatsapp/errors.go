@@ -0,0 +1,87 @@
+package whatsapp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotFound matches Graph API errors for objects that do not exist, e.g.
+// deleting a product that was already removed. Use errors.Is to check for it.
+var ErrNotFound = errors.New("not found")
+
+// Graph API codes reported for missing objects
+const (
+	errCodeInvalidParameter = 100 // With errSubcodeObjectMissing: object does not exist
+	errCodeAliasesMissing   = 803 // Some of the aliases requested do not exist
+	errSubcodeObjectMissing = 33
+)
+
+// GraphAPIError is returned when Meta's Graph API responds with a non-200 status.
+// Use errors.As to inspect the error code, e.g. 190 for an expired access token.
+type GraphAPIError struct {
+	HTTPStatus   int
+	Message      string
+	Type         string
+	Code         int
+	ErrorSubcode int
+	UserMessage  string // error_user_msg, suitable for showing to end users
+	Details      string // error_data.details
+	FBTraceID    string
+	RawBody      string // Response body, set when it is not a Graph API error object
+}
+
+// Error implements the error interface
+func (e *GraphAPIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("API returned status %d: %s", e.HTTPStatus, e.RawBody)
+	}
+
+	msg := fmt.Sprintf("API error %d: %s", e.Code, e.Message)
+	if e.Details != "" {
+		msg += " - Details: " + e.Details
+	}
+	if e.UserMessage != "" {
+		msg += " - " + e.UserMessage
+	}
+	return msg
+}
+
+// Is lets errors.Is match ErrNotFound for errors about missing objects
+func (e *GraphAPIError) Is(target error) bool {
+	return target == ErrNotFound && e.notFound()
+}
+
+// notFound reports whether Meta rejected the request because the object does not exist
+func (e *GraphAPIError) notFound() bool {
+	if e.HTTPStatus == http.StatusNotFound || e.Code == errCodeAliasesMissing {
+		return true
+	}
+	return e.Code == errCodeInvalidParameter && e.ErrorSubcode == errSubcodeObjectMissing
+}
+
+// Retryable reports whether the error is transient and the request may succeed later
+func (e *GraphAPIError) Retryable() bool {
+	return isRetryableStatus(e.HTTPStatus) || transientErrorCodes[e.Code]
+}
+
+// parseGraphAPIError builds a GraphAPIError from a non-200 response
+func parseGraphAPIError(status int, body []byte) *GraphAPIError {
+	apiErr := &GraphAPIError{HTTPStatus: status}
+
+	var resp MetaAPIError
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error.Message == "" {
+		apiErr.RawBody = string(body)
+		return apiErr
+	}
+
+	apiErr.Message = resp.Error.Message
+	apiErr.Type = resp.Error.Type
+	apiErr.Code = resp.Error.Code
+	apiErr.ErrorSubcode = resp.Error.ErrorSubcode
+	apiErr.UserMessage = resp.Error.ErrorUserMsg
+	apiErr.Details = resp.Error.ErrorData.Details
+	apiErr.FBTraceID = resp.Error.FBTraceID
+	return apiErr
+}
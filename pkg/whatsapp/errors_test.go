@@ -0,0 +1,149 @@
+package whatsapp_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphAPIError_FromResponse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"Error validating access token","type":"OAuthException","code":190,"error_subcode":463,"fbtrace_id":"AbC123"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.HTTPStatus)
+	assert.Equal(t, 190, apiErr.Code)
+	assert.Equal(t, 463, apiErr.ErrorSubcode)
+	assert.Equal(t, "OAuthException", apiErr.Type)
+	assert.Equal(t, "AbC123", apiErr.FBTraceID)
+	assert.Equal(t, "API error 190: Error validating access token", apiErr.Error())
+	assert.False(t, apiErr.Retryable())
+}
+
+func TestGraphAPIError_AfterRetries(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":80004}}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 80004, apiErr.Code)
+	assert.True(t, apiErr.Retryable())
+}
+
+func TestGraphAPIError_NonGraphBody(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream unavailable"))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.HTTPStatus)
+	assert.Equal(t, "upstream unavailable", apiErr.RawBody)
+	assert.Zero(t, apiErr.Code)
+	assert.Contains(t, err.Error(), "API returned status 502")
+}
+
+func TestGraphAPIError_NotFound(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		notFound bool
+	}{
+		{
+			name:     "missing object",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"message":"Unsupported delete request. Object with ID 'prod-1' does not exist","type":"GraphMethodException","code":100,"error_subcode":33}}`,
+			notFound: true,
+		},
+		{
+			name:     "http 404",
+			status:   http.StatusNotFound,
+			body:     `{"error":{"message":"Unknown path components","code":2500}}`,
+			notFound: true,
+		},
+		{
+			name:   "other invalid parameter",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := newTestClient(t, server)
+			err := client.DeleteProduct(context.Background(), testAccount(server.URL), "prod-1")
+			require.Error(t, err)
+			assert.Equal(t, tt.notFound, errors.Is(err, whatsapp.ErrNotFound))
+
+			var apiErr *whatsapp.GraphAPIError
+			assert.True(t, errors.As(err, &apiErr), "not-found errors keep the Graph API details")
+		})
+	}
+}
+
+func TestErrNotFound_MatchesSpecificErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, whatsapp.ErrProductNotFound, whatsapp.ErrNotFound)
+	assert.ErrorIs(t, whatsapp.ErrTemplateNotFound, whatsapp.ErrNotFound)
+	assert.Equal(t, "product not found", whatsapp.ErrProductNotFound.Error())
+}
+
+func TestGraphAPIError_TraceIDFromHeader(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-FB-Trace-ID", "trace-header")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","code":100}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+
+	var apiErr *whatsapp.GraphAPIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "trace-header", apiErr.FBTraceID)
+}
@@ -5,10 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
+	"net/url"
+	"strings"
 )
 
 // FlowCreateRequest represents the request to create a flow
@@ -45,12 +46,7 @@ type FlowGetResponse struct {
 // FlowListResponse represents the response from listing flows
 type FlowListResponse struct {
 	Data   []FlowGetResponse `json:"data"`
-	Paging struct {
-		Cursors struct {
-			Before string `json:"before"`
-			After  string `json:"after"`
-		} `json:"cursors"`
-	} `json:"paging"`
+	Paging Paging            `json:"paging"`
 }
 
 // FlowJSON represents the flow definition
@@ -61,29 +57,94 @@ type FlowJSON struct {
 	Screens       []interface{} `json:"screens"`
 }
 
+// FlowDefinition describes a flow to create in a single call, optionally with
+// its JSON spec and publishing it right away
+type FlowDefinition struct {
+	Name       string
+	Categories []string  // e.g. SIGN_UP, APPOINTMENT_BOOKING, OTHER
+	FlowJSON   *FlowJSON // Optional; the flow is created empty when nil
+	Publish    bool      // Publish immediately; requires FlowJSON
+}
+
+// FlowValidationError is a single problem Meta found in a flow's JSON
+type FlowValidationError struct {
+	Error       string `json:"error"`
+	ErrorType   string `json:"error_type"`
+	Message     string `json:"message"`
+	LineStart   int    `json:"line_start,omitempty"`
+	LineEnd     int    `json:"line_end,omitempty"`
+	ColumnStart int    `json:"column_start,omitempty"`
+	ColumnEnd   int    `json:"column_end,omitempty"`
+}
+
+// FlowValidationErrors is returned when Meta rejects a flow's JSON. The
+// messages are reported exactly as Meta returned them.
+type FlowValidationErrors []FlowValidationError
+
+// Error implements the error interface
+func (e FlowValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, v := range e {
+		msg := v.Message
+		if v.LineStart > 0 {
+			msg = fmt.Sprintf("line %d: %s", v.LineStart, msg)
+		}
+		messages = append(messages, msg)
+	}
+	return "flow validation errors: " + strings.Join(messages, "; ")
+}
+
 // CreateFlow creates a new flow in Meta
 func (c *Client) CreateFlow(ctx context.Context, account *Account, name string, categories []string) (string, error) {
+	return c.CreateFlowFromDefinition(ctx, account, FlowDefinition{Name: name, Categories: categories})
+}
+
+// CreateFlowFromDefinition creates a flow, uploading its JSON and publishing it
+// in the same request when set. If Meta creates the flow but finds problems in
+// its JSON, the flow ID is returned together with a FlowValidationErrors error.
+func (c *Client) CreateFlowFromDefinition(ctx context.Context, account *Account, flow FlowDefinition) (string, error) {
+	if flow.Publish && flow.FlowJSON == nil {
+		return "", fmt.Errorf("flow JSON is required to publish a flow")
+	}
+
 	url := c.buildFlowsURL(account)
 
-	payload := FlowCreateRequest{
-		Name:       name,
-		Categories: categories,
+	payload := map[string]interface{}{
+		"name":       flow.Name,
+		"categories": flow.Categories,
+	}
+	if flow.FlowJSON != nil {
+		// Meta expects the flow JSON as a string, not a nested object
+		jsonBytes, err := json.Marshal(flow.FlowJSON)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal flow JSON: %w", err)
+		}
+		payload["flow_json"] = string(jsonBytes)
+		payload["publish"] = flow.Publish
 	}
 
-	c.Log.Info("Creating flow in Meta", "name", name, "categories", categories, "url", url, "business_id", account.BusinessID)
+	c.Log.Info("Creating flow in Meta", "name", flow.Name, "categories", flow.Categories, "url", url, "business_id", account.BusinessID)
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, url, payload, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, payload, account)
 	if err != nil {
-		c.Log.Error("Failed to create flow", "error", err, "name", name, "url", url)
+		c.Log.Error("Failed to create flow", "error", err, "name", flow.Name, "url", url)
 		return "", err
 	}
 
-	var result FlowCreateResponse
+	var result struct {
+		ID               string               `json:"id"`
+		ValidationErrors FlowValidationErrors `json:"validation_errors,omitempty"`
+	}
 	if err := json.Unmarshal(respBody, &result); err != nil {
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	c.Log.Info("Flow created in Meta", "flow_id", result.ID, "name", name)
+	if len(result.ValidationErrors) > 0 {
+		c.Log.Warn("Flow created with validation errors", "flow_id", result.ID, "errors", len(result.ValidationErrors))
+		return result.ID, result.ValidationErrors
+	}
+
+	c.Log.Info("Flow created in Meta", "flow_id", result.ID, "name", flow.Name)
 	return result.ID, nil
 }
 
@@ -129,34 +190,25 @@ func (c *Client) UpdateFlowJSON(ctx context.Context, account *Account, flowID st
 		return fmt.Errorf("failed to close multipart writer: %w", err)
 	}
 
+	accessToken, err := account.accessToken(ctx, false)
+	if err != nil {
+		return err
+	}
+
 	// Create request
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &buf)
+	req, err := c.newRequest(ctx, http.MethodPost, url, &buf)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+account.AccessToken)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
 	c.Log.Info("Updating flow JSON", "flow_id", flowID)
 
-	resp, err := c.HTTPClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, _, _, err := c.roundTrip(req, 1)
 	if err != nil {
-		return fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		var apiErr MetaAPIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil && apiErr.Error.Message != "" {
-			return fmt.Errorf("API error %d: %s", apiErr.Error.Code, apiErr.Error.Message)
-		}
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(respBody))
+		return err
 	}
 
 	var result FlowUpdateResponse
@@ -181,7 +233,7 @@ func (c *Client) PublishFlow(ctx context.Context, account *Account, flowID strin
 
 	c.Log.Info("Publishing flow", "flow_id", flowID)
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account)
 	if err != nil {
 		c.Log.Error("Failed to publish flow", "error", err, "flow_id", flowID)
 		return err
@@ -206,7 +258,7 @@ func (c *Client) DeprecateFlow(ctx context.Context, account *Account, flowID str
 
 	c.Log.Info("Deprecating flow", "flow_id", flowID)
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, nil, account)
 	if err != nil {
 		c.Log.Error("Failed to deprecate flow", "error", err, "flow_id", flowID)
 		return err
@@ -231,7 +283,7 @@ func (c *Client) DeleteFlow(ctx context.Context, account *Account, flowID string
 
 	c.Log.Info("Deleting flow from Meta", "flow_id", flowID)
 
-	_, err := c.doRequest(ctx, http.MethodDelete, url, nil, account.AccessToken)
+	_, err := c.doRequest(ctx, http.MethodDelete, url, nil, account)
 	if err != nil {
 		c.Log.Error("Failed to delete flow", "error", err, "flow_id", flowID)
 		return err
@@ -245,7 +297,7 @@ func (c *Client) DeleteFlow(ctx context.Context, account *Account, flowID string
 func (c *Client) GetFlow(ctx context.Context, account *Account, flowID string) (*FlowGetResponse, error) {
 	url := fmt.Sprintf("%s/%s/%s?fields=id,name,status,categories,preview.invalidate(false)", c.getBaseURL(), account.APIVersion, flowID)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account)
 	if err != nil {
 		c.Log.Error("Failed to get flow", "error", err, "flow_id", flowID)
 		return nil, err
@@ -275,7 +327,7 @@ func (c *Client) GetFlowAssets(ctx context.Context, account *Account, flowID str
 
 	c.Log.Info("Fetching flow assets", "flow_id", flowID, "url", assetsURL)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, assetsURL, nil, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodGet, assetsURL, nil, account)
 	if err != nil {
 		c.Log.Error("Failed to get flow assets", "error", err, "flow_id", flowID)
 		return nil, err
@@ -300,12 +352,17 @@ func (c *Client) GetFlowAssets(ctx context.Context, account *Account, flowID str
 		return nil, nil // No flow JSON yet
 	}
 
+	accessToken, err := account.accessToken(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
 	// Download the flow JSON
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, downloadURL, nil)
+	req, err := c.newRequest(ctx, http.MethodGet, downloadURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create download request: %w", err)
 	}
-	req.Header.Set("Authorization", "Bearer "+account.AccessToken)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -313,7 +370,7 @@ func (c *Client) GetFlowAssets(ctx context.Context, account *Account, flowID str
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	flowJSONBody, err := io.ReadAll(resp.Body)
+	flowJSONBody, err := c.readResponse(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read flow JSON: %w", err)
 	}
@@ -332,23 +389,21 @@ func (c *Client) GetFlowAssets(ctx context.Context, account *Account, flowID str
 	return &flowJSON, nil
 }
 
-// ListFlows fetches all flows from Meta
+// ListFlows fetches all flows from Meta, following pagination
 func (c *Client) ListFlows(ctx context.Context, account *Account) ([]FlowGetResponse, error) {
-	url := fmt.Sprintf("%s?fields=id,name,status,categories,preview.invalidate(false)", c.buildFlowsURL(account))
-
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+	params := url.Values{}
+	params.Add("fields", "id,name,status,categories,preview.invalidate(false)")
+	flows, err := listAll[FlowGetResponse](ctx, c, account, c.buildFlowsURL(account), params)
 	if err != nil {
 		c.Log.Error("Failed to list flows", "error", err)
 		return nil, err
 	}
-
-	var result FlowListResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if flows == nil {
+		flows = make([]FlowGetResponse, 0)
 	}
 
-	c.Log.Info("Fetched flows from Meta", "count", len(result.Data))
-	return result.Data, nil
+	c.Log.Info("Fetched flows from Meta", "count", len(flows))
+	return flows, nil
 }
 
 // buildFlowsURL builds the flows endpoint URL
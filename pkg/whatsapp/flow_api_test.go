@@ -269,6 +269,100 @@ func TestClient_ListFlows_Empty(t *testing.T) {
 	assert.Empty(t, flows)
 }
 
+func TestClient_ListFlows_FollowsPaging(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_, _ = w.Write([]byte(`{"data":[{"id":"f1","name":"Flow 1"}],"paging":{"cursors":{"after":"c1"},"next":"https://graph.facebook.com/next"}}`))
+			return
+		}
+		assert.Equal(t, "c1", r.URL.Query().Get("after"))
+		_, _ = w.Write([]byte(`{"data":[{"id":"f2","name":"Flow 2"}],"paging":{"cursors":{"after":"c2"}}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	flows, err := client.ListFlows(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	require.Len(t, flows, 2)
+	assert.Equal(t, "f2", flows[1].ID)
+}
+
+// --- CreateFlowFromDefinition ---
+
+func TestClient_CreateFlowFromDefinition_Publish(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/987654321/flows", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Signup", body["name"])
+		assert.Equal(t, []interface{}{"SIGN_UP"}, body["categories"])
+		assert.Equal(t, true, body["publish"])
+
+		flowJSON, ok := body["flow_json"].(string)
+		require.True(t, ok, "flow_json must be sent as a string")
+		assert.JSONEq(t, `{"version":"6.0","screens":[{"id":"WELCOME"}]}`, flowJSON)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"flow-1","success":true,"validation_errors":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	id, err := client.CreateFlowFromDefinition(context.Background(), testAccount(server.URL), whatsapp.FlowDefinition{
+		Name:       "Signup",
+		Categories: []string{"SIGN_UP"},
+		FlowJSON:   &whatsapp.FlowJSON{Version: "6.0", Screens: []interface{}{map[string]string{"id": "WELCOME"}}},
+		Publish:    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "flow-1", id)
+}
+
+func TestClient_CreateFlowFromDefinition_ValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"flow-2","success":true,"validation_errors":[
+			{"error":"INVALID_PROPERTY_VALUE","error_type":"FLOW_JSON_ERROR","message":"Invalid value found for property 'type'.","line_start":10,"line_end":10,"column_start":21,"column_end":34}
+		]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	id, err := client.CreateFlowFromDefinition(context.Background(), testAccount(server.URL), whatsapp.FlowDefinition{
+		Name:     "Broken",
+		FlowJSON: &whatsapp.FlowJSON{Version: "6.0"},
+	})
+	assert.Equal(t, "flow-2", id)
+
+	var validationErrs whatsapp.FlowValidationErrors
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs, 1)
+	assert.Equal(t, "INVALID_PROPERTY_VALUE", validationErrs[0].Error)
+	assert.Equal(t, 21, validationErrs[0].ColumnStart)
+	assert.Contains(t, err.Error(), "line 10: Invalid value found for property 'type'.")
+}
+
+func TestClient_CreateFlowFromDefinition_PublishRequiresJSON(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid flow should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateFlowFromDefinition(context.Background(), testAccount(server.URL), whatsapp.FlowDefinition{Name: "Empty", Publish: true})
+	require.Error(t, err)
+}
+
 // --- UpdateFlowJSON ---
 
 func TestClient_UpdateFlowJSON_Success(t *testing.T) {
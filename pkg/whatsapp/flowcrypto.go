@@ -0,0 +1,129 @@
+package whatsapp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// ErrFlowDecryption is returned when a flow data-exchange request cannot be
+// decrypted. Meta expects the endpoint to answer such requests with HTTP 421
+// so the client re-fetches the public key and retries.
+var ErrFlowDecryption = errors.New("failed to decrypt flow request")
+
+// encryptedFlowRequest is the body Meta posts to a flow's data endpoint
+type encryptedFlowRequest struct {
+	EncryptedFlowData string `json:"encrypted_flow_data"`
+	EncryptedAESKey   string `json:"encrypted_aes_key"`
+	InitialVector     string `json:"initial_vector"`
+}
+
+// DecryptFlowRequest decrypts the body of a flow data-exchange request with the
+// business's RSA private key (PKCS#1 or PKCS#8 PEM, unencrypted). It returns the
+// JSON payload along with the AES key and IV needed by EncryptFlowResponse.
+func DecryptFlowRequest(body []byte, privateKeyPEM []byte) (plaintext []byte, aesKey []byte, iv []byte, err error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var req encryptedFlowRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to parse flow request: %w", err)
+	}
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(req.EncryptedAESKey)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: invalid encrypted_aes_key: %w", ErrFlowDecryption, err)
+	}
+	flowData, err := base64.StdEncoding.DecodeString(req.EncryptedFlowData)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: invalid encrypted_flow_data: %w", ErrFlowDecryption, err)
+	}
+	iv, err = base64.StdEncoding.DecodeString(req.InitialVector)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: invalid initial_vector: %w", ErrFlowDecryption, err)
+	}
+
+	aesKey, err = rsa.DecryptOAEP(sha256.New(), nil, privateKey, encryptedKey, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: AES key: %w", ErrFlowDecryption, err)
+	}
+
+	gcm, err := newFlowGCM(aesKey, iv)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrFlowDecryption, err)
+	}
+	// The GCM tag is appended to the ciphertext, as Open expects
+	plaintext, err = gcm.Open(nil, iv, flowData, nil)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: flow data: %w", ErrFlowDecryption, err)
+	}
+
+	return plaintext, aesKey, iv, nil
+}
+
+// EncryptFlowResponse encrypts a data-exchange response with the AES key from
+// the request and the bitwise-inverted request IV, returning the base64 string
+// to send as the response body
+func EncryptFlowResponse(plaintext, aesKey, iv []byte) ([]byte, error) {
+	flippedIV := make([]byte, len(iv))
+	for i, b := range iv {
+		flippedIV[i] = ^b
+	}
+
+	gcm, err := newFlowGCM(aesKey, flippedIV)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext := gcm.Seal(nil, flippedIV, plaintext, nil)
+
+	encoded := make([]byte, base64.StdEncoding.EncodedLen(len(ciphertext)))
+	base64.StdEncoding.Encode(encoded, ciphertext)
+	return encoded, nil
+}
+
+// newFlowGCM returns an AES-GCM cipher using the full IV as the nonce. Meta
+// sends 16-byte IVs rather than GCM's standard 12 bytes.
+func newFlowGCM(aesKey, iv []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AES key: %w", err)
+	}
+	if len(iv) == 0 {
+		return nil, fmt.Errorf("initial vector is required")
+	}
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM cipher: %w", err)
+	}
+	return gcm, nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#1 or PKCS#8 RSA private key
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode private key PEM")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+	return key, nil
+}
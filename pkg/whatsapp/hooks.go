@@ -0,0 +1,147 @@
+package whatsapp
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestPhase identifies when a RequestLogger is called
+type RequestPhase int
+
+const (
+	// RequestStarted is logged right before a request is sent
+	RequestStarted RequestPhase = iota
+	// RequestFinished is logged once a response (or transport error) is received
+	RequestFinished
+)
+
+// RequestLogEntry describes a Graph API request for a RequestLogger.
+// Tokens in the URL and the Authorization header are redacted.
+type RequestLogEntry struct {
+	Phase    RequestPhase
+	Method   string
+	URL      string
+	Header   http.Header
+	Attempt  int           // 1-based attempt number when retries are enabled
+	Status   int           // HTTP status; 0 before the response or on transport errors
+	Duration time.Duration // Time until the response headers arrived; set when finished
+	Err      error         // Transport error, set when finished
+	// FBTraceID is the response's x-fb-trace-id, set when finished
+	FBTraceID string
+}
+
+// RequestLogger receives a log entry before and after each Graph API request.
+// It is separate from the client's Log so request tracing can be routed to a
+// debug sink without changing the client's regular logging.
+type RequestLogger interface {
+	LogRequest(entry RequestLogEntry)
+}
+
+// redacted replaces secrets in logged URLs and headers
+const redacted = "REDACTED"
+
+// sensitiveQueryParams are query parameters that carry credentials
+var sensitiveQueryParams = []string{"access_token", "input_token", "appsecret_proof", "client_secret", "code", "fb_exchange_token"}
+
+// logRequest passes the entry to the configured RequestLogger, if any
+func (c *Client) logRequest(entry RequestLogEntry) {
+	if c.RequestLog == nil {
+		return
+	}
+	entry.URL = redactURL(entry.URL)
+	entry.Header = redactHeader(entry.Header)
+	c.RequestLog.LogRequest(entry)
+}
+
+// redactURL masks credential query parameters in a URL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	changed := false
+	for _, param := range sensitiveQueryParams {
+		if query.Has(param) {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// redactURLError masks credential query parameters in the URL quoted by a
+// transport error, so they do not leak into returned errors and logs
+func redactURLError(err error) {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+}
+
+// redactHeader returns a copy of the header with the Authorization value masked
+func redactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	clone := header.Clone()
+	if clone.Get("Authorization") != "" {
+		clone.Set("Authorization", "Bearer "+redacted)
+	}
+	return clone
+}
+
+// Observer receives metrics for each Graph API request attempt, e.g. to feed
+// Prometheus counters and histograms. status is 0 when no response was received.
+type Observer interface {
+	ObserveRequest(method, endpoint string, status int, duration time.Duration)
+}
+
+// nodeEndpoint is the endpoint label for requests to an object by ID
+const nodeEndpoint = "node"
+
+// observeRequest passes request metrics to the configured Observer, if any
+func (c *Client) observeRequest(method, rawURL string, status int, duration time.Duration) {
+	if c.Observer == nil {
+		return
+	}
+	c.Observer.ObserveRequest(method, endpointLabel(rawURL), status, duration)
+}
+
+// endpointLabel reduces a Graph API URL to a low-cardinality label: the last
+// edge name in the path (e.g. "messages", "owned_product_catalogs"), or "node"
+// for requests addressing an object by ID
+func endpointLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nodeEndpoint
+	}
+
+	label := nodeEndpoint
+	for _, segment := range strings.Split(u.Path, "/") {
+		if isEdgeName(segment) {
+			label = segment
+		}
+	}
+	return label
+}
+
+// isEdgeName reports whether a path segment is an edge name rather than an ID
+// or API version. Graph API edges are lowercase words joined by underscores.
+func isEdgeName(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, r := range segment {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingRequestLogger struct {
+	mu      sync.Mutex
+	entries []whatsapp.RequestLogEntry
+}
+
+func (l *recordingRequestLogger) LogRequest(entry whatsapp.RequestLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+}
+
+func TestClient_RequestLogger(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-access-token", r.Header.Get("Authorization"))
+		w.Header().Set("X-FB-Trace-ID", "trace-abc")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(whatsapp.MediaURLResponse{URL: "https://lookaside.test/media"})
+	}))
+	defer server.Close()
+
+	logger := &recordingRequestLogger{}
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithRequestLogger(logger))
+
+	// The media ID is used as a path segment, so it can carry a token-bearing query
+	_, err := client.GetMediaURL(context.Background(), "media-1?access_token=secret-token", testAccount(server.URL))
+	require.NoError(t, err)
+
+	require.Len(t, logger.entries, 2)
+	started, finished := logger.entries[0], logger.entries[1]
+
+	assert.Equal(t, whatsapp.RequestStarted, started.Phase)
+	assert.Equal(t, http.MethodGet, started.Method)
+	assert.Equal(t, 1, started.Attempt)
+	assert.Zero(t, started.Status)
+
+	assert.Equal(t, whatsapp.RequestFinished, finished.Phase)
+	assert.Equal(t, http.StatusOK, finished.Status)
+	assert.Positive(t, finished.Duration)
+	assert.NoError(t, finished.Err)
+	assert.Equal(t, "trace-abc", finished.FBTraceID)
+
+	for _, entry := range logger.entries {
+		assert.NotContains(t, entry.URL, "secret-token")
+		assert.Contains(t, entry.URL, "access_token=REDACTED")
+		assert.Equal(t, "Bearer REDACTED", entry.Header.Get("Authorization"))
+	}
+}
+
+func TestClient_RequestLogger_TransportError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	logger := &recordingRequestLogger{}
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(serverURL), whatsapp.WithRequestLogger(logger))
+
+	_, err := client.ListCatalogs(context.Background(), testAccount(serverURL))
+	require.Error(t, err)
+
+	require.Len(t, logger.entries, 2)
+	assert.Error(t, logger.entries[1].Err)
+	assert.Zero(t, logger.entries[1].Status)
+}
+
+type observation struct {
+	method   string
+	endpoint string
+	status   int
+}
+
+type recordingObserver struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (o *recordingObserver) ObserveRequest(method, endpoint string, status int, duration time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.observations = append(o.observations, observation{method, endpoint, status})
+}
+
+func TestClient_Observer_EndpointLabels(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v21.0/prod-missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[],"id":"1","success":true}`))
+	}))
+	defer server.Close()
+
+	observer := &recordingObserver{}
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithObserver(observer))
+	ctx := context.Background()
+	account := testAccount(server.URL)
+
+	_, _ = client.ListCatalogs(ctx, account)
+	_, _ = client.ListCatalogProducts(ctx, account, "1234567890")
+	_ = client.DeleteProduct(ctx, account, "98765")
+	_ = client.DeleteProduct(ctx, account, "prod-missing")
+
+	assert.Equal(t, []observation{
+		{http.MethodGet, "owned_product_catalogs", http.StatusOK},
+		{http.MethodGet, "products", http.StatusOK},
+		{http.MethodDelete, "node", http.StatusOK},
+		{http.MethodDelete, "node", http.StatusNotFound},
+	}, observer.observations)
+}
@@ -2,59 +2,211 @@ package whatsapp
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
-// SendTextMessage sends a text message to a phone number with optional reply context
-func (c *Client) SendTextMessage(ctx context.Context, account *Account, phoneNumber, text string, replyToMsgID ...string) (string, error) {
-	payload := map[string]any{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "text",
-		"text": map[string]any{
-			"preview_url": false,
-			"body":        text,
-		},
+// maxPhoneDigits is the maximum number of digits in an E.164 phone number
+const maxPhoneDigits = 15
+
+// validatePhoneNumber checks that a recipient is an E.164-style number:
+// digits only, optionally prefixed with "+", and at most 15 digits long
+func validatePhoneNumber(phoneNumber string) error {
+	digits := strings.TrimPrefix(phoneNumber, "+")
+	if digits == "" {
+		return fmt.Errorf("recipient phone number is required")
+	}
+	if len(digits) > maxPhoneDigits {
+		return fmt.Errorf("invalid recipient phone number %q: exceeds %d digits", phoneNumber, maxPhoneDigits)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return fmt.Errorf("invalid recipient phone number %q: must contain digits only, including country code", phoneNumber)
+		}
+	}
+	return nil
+}
+
+// maxTextBody is the maximum length of a text message body in characters
+const maxTextBody = 4096
+
+// validateTextBody checks that a text body fits the WhatsApp length limit,
+// counted in characters as Meta does
+func validateTextBody(text string) error {
+	if n := utf8.RuneCountInString(text); n > maxTextBody {
+		return fmt.Errorf("text body is %d characters, exceeds maximum of %d", n, maxTextBody)
+	}
+	return nil
+}
+
+// SendTextMessage sends a text message to a phone number. Use WithReplyTo to
+// send it as a reply and WithPreviewURL to preview a link in the body.
+func (c *Client) SendTextMessage(ctx context.Context, account *Account, phoneNumber, text string, opts ...SendOption) (string, error) {
+	if err := validatePhoneNumber(phoneNumber); err != nil {
+		return "", err
 	}
+	if err := validateTextBody(text); err != nil {
+		return "", err
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "text", map[string]interface{}{
+		"preview_url": false,
+		"body":        text,
+	}, opts...)
+}
+
+// SendLocationMessage sends a location pin. name and address are optional.
+func (c *Client) SendLocationMessage(ctx context.Context, account *Account, phoneNumber string, latitude, longitude float64, name, address string, opts ...SendOption) (string, error) {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return "", fmt.Errorf("latitude must be between -90 and 90, got %v", latitude)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return "", fmt.Errorf("longitude must be between -180 and 180, got %v", longitude)
+	}
+
+	location := map[string]interface{}{
+		"latitude":  latitude,
+		"longitude": longitude,
+	}
+	if name != "" {
+		location["name"] = name
+	}
+	if address != "" {
+		location["address"] = address
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "location", location, opts...)
+}
 
-	// Add reply context if provided
-	if len(replyToMsgID) > 0 && replyToMsgID[0] != "" {
-		payload["context"] = map[string]any{
-			"message_id": replyToMsgID[0],
+// SendContactsMessage sends one or more contact cards
+func (c *Client) SendContactsMessage(ctx context.Context, account *Account, phoneNumber string, contacts []Contact, opts ...SendOption) (string, error) {
+	if len(contacts) == 0 {
+		return "", fmt.Errorf("at least one contact is required")
+	}
+	for i, contact := range contacts {
+		if strings.TrimSpace(contact.Name.FormattedName) == "" {
+			return "", fmt.Errorf("contact %d: formatted name is required", i+1)
 		}
 	}
 
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending text message", "phone", phoneNumber, "url", url)
+	return c.sendMessage(ctx, account, phoneNumber, "contacts", contacts, opts...)
+}
+
+// MediaRef references the media of a message, either an uploaded media ID or
+// a link. Exactly one of ID and Link must be set.
+type MediaRef struct {
+	ID   string // ID returned by UploadMedia
+	Link string // Publicly reachable HTTPS URL
+	// MimeType optionally declares the media type so it can be checked before
+	// sending (audio and stickers); it is not sent to Meta
+	MimeType string
+}
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send text message", "error", err, "phone", phoneNumber)
-		return "", fmt.Errorf("failed to send text message: %w", err)
+// audioMimeTypes are the audio formats WhatsApp accepts
+var audioMimeTypes = map[string]bool{
+	"audio/aac":  true,
+	"audio/amr":  true,
+	"audio/mpeg": true,
+	"audio/mp4":  true,
+	"audio/ogg":  true, // OPUS codec only
+}
+
+// stickerMimeType is the only format WhatsApp accepts for stickers
+const stickerMimeType = "image/webp"
+
+// DocumentMessage is a document to send by media ID or by link.
+// Exactly one of MediaID and Link must be set.
+type DocumentMessage struct {
+	MediaID  string // ID returned by UploadMedia
+	Link     string // Publicly reachable HTTPS URL
+	Filename string // Shown to the recipient and used when saving the file
+	Caption  string
+}
+
+// SendDocument sends a document by media ID or link and returns the message ID.
+// Unlike SendDocumentMessage it also accepts links.
+func (c *Client) SendDocument(ctx context.Context, account *Account, phoneNumber string, doc DocumentMessage, opts ...SendOption) (string, error) {
+	extra := map[string]string{"filename": doc.Filename, "caption": doc.Caption}
+	return c.sendMedia(ctx, account, phoneNumber, "document", MediaRef{ID: doc.MediaID, Link: doc.Link}, extra, opts...)
+}
+
+// SendImage sends an image by media ID or link with an optional caption
+func (c *Client) SendImage(ctx context.Context, account *Account, phoneNumber string, media MediaRef, caption string, opts ...SendOption) (string, error) {
+	return c.sendMedia(ctx, account, phoneNumber, "image", media, map[string]string{"caption": caption}, opts...)
+}
+
+// SendVideo sends a video by media ID or link with an optional caption
+func (c *Client) SendVideo(ctx context.Context, account *Account, phoneNumber string, media MediaRef, caption string, opts ...SendOption) (string, error) {
+	return c.sendMedia(ctx, account, phoneNumber, "video", media, map[string]string{"caption": caption}, opts...)
+}
+
+// SendAudio sends an audio file or voice note by media ID or link.
+// A declared MimeType must be one of the formats WhatsApp plays back.
+func (c *Client) SendAudio(ctx context.Context, account *Account, phoneNumber string, media MediaRef, opts ...SendOption) (string, error) {
+	if media.MimeType != "" && !audioMimeTypes[baseMimeType(media.MimeType)] {
+		return "", fmt.Errorf("unsupported audio type %q", media.MimeType)
 	}
+	return c.sendMedia(ctx, account, phoneNumber, "audio", media, nil, opts...)
+}
 
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+// SendSticker sends a sticker by media ID or link. Stickers must be WebP, so
+// a declared MimeType other than image/webp is rejected.
+func (c *Client) SendSticker(ctx context.Context, account *Account, phoneNumber string, media MediaRef, opts ...SendOption) (string, error) {
+	if media.MimeType != "" && baseMimeType(media.MimeType) != stickerMimeType {
+		return "", fmt.Errorf("stickers must be %s, got %q", stickerMimeType, media.MimeType)
 	}
+	return c.sendMedia(ctx, account, phoneNumber, "sticker", media, nil, opts...)
+}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+// baseMimeType strips parameters such as "; codecs=opus" from a MIME type
+func baseMimeType(mimeType string) string {
+	if i := strings.IndexByte(mimeType, ';'); i >= 0 {
+		mimeType = mimeType[:i]
 	}
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Text message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+// sendMedia sends a media message of the given type. Empty values in extra,
+// such as a caption or filename, are left out of the media object.
+func (c *Client) sendMedia(ctx context.Context, account *Account, phoneNumber, msgType string, media MediaRef, extra map[string]string, opts ...SendOption) (string, error) {
+	object, err := buildMediaObject(media)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range extra {
+		if value != "" {
+			object[key] = value
+		}
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, msgType, object, opts...)
+}
+
+// buildMediaObject builds the media object of a message, referencing either
+// an uploaded media ID or a link
+func buildMediaObject(media MediaRef) (map[string]interface{}, error) {
+	switch {
+	case media.ID != "" && media.Link != "":
+		return nil, fmt.Errorf("media ID and link are mutually exclusive")
+	case media.ID != "":
+		return map[string]interface{}{"id": media.ID}, nil
+	case media.Link != "":
+		return map[string]interface{}{"link": media.Link}, nil
+	default:
+		return nil, fmt.Errorf("media ID or link is required")
+	}
 }
 
 // SendInteractiveButtons sends an interactive message with buttons or list
 // If buttons <= 3, sends as buttons; if 4-10, sends as list
-func (c *Client) SendInteractiveButtons(ctx context.Context, account *Account, phoneNumber, bodyText string, buttons []Button) (string, error) {
+func (c *Client) SendInteractiveButtons(ctx context.Context, account *Account, phoneNumber, bodyText string, buttons []Button, opts ...SendOption) (string, error) {
 	if len(buttons) == 0 {
 		return "", fmt.Errorf("at least one button is required")
 	}
@@ -121,40 +273,259 @@ func (c *Client) SendInteractiveButtons(ctx context.Context, account *Account, p
 		}
 	}
 
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "interactive",
-		"interactive":       interactive,
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+const (
+	// maxReplyButtons is the maximum number of quick reply buttons in a message
+	maxReplyButtons = 3
+	// maxReplyButtonTitle is the maximum length of a reply button title in characters
+	maxReplyButtonTitle = 20
+	// maxReplyButtonID is the maximum length of a reply button ID in characters
+	maxReplyButtonID = 256
+)
+
+// SendButtonMessage sends an interactive message with up to three quick reply
+// buttons. Only the ID and Title of each button are used. Unlike
+// SendInteractiveButtons, titles are never truncated and limits are enforced
+// before calling the API.
+func (c *Client) SendButtonMessage(ctx context.Context, account *Account, phoneNumber, bodyText string, buttons []Button, opts ...SendOption) (string, error) {
+	if bodyText == "" {
+		return "", fmt.Errorf("body text is required")
+	}
+	if len(buttons) == 0 || len(buttons) > maxReplyButtons {
+		return "", fmt.Errorf("between 1 and %d buttons are required, got %d", maxReplyButtons, len(buttons))
 	}
 
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending interactive message", "phone", phoneNumber, "button_count", len(buttons))
+	seen := make(map[string]bool, len(buttons))
+	buttonsList := make([]map[string]interface{}, 0, len(buttons))
+	for i, btn := range buttons {
+		if btn.ID == "" || btn.Title == "" {
+			return "", fmt.Errorf("button %d: ID and title are required", i+1)
+		}
+		if utf8.RuneCountInString(btn.Title) > maxReplyButtonTitle {
+			return "", fmt.Errorf("button %d: title exceeds %d characters", i+1, maxReplyButtonTitle)
+		}
+		if utf8.RuneCountInString(btn.ID) > maxReplyButtonID {
+			return "", fmt.Errorf("button %d: ID exceeds %d characters", i+1, maxReplyButtonID)
+		}
+		if seen[btn.ID] {
+			return "", fmt.Errorf("button %d: duplicate ID %q", i+1, btn.ID)
+		}
+		seen[btn.ID] = true
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send interactive message", "error", err, "phone", phoneNumber)
-		return "", fmt.Errorf("failed to send interactive message: %w", err)
+		buttonsList = append(buttonsList, map[string]interface{}{
+			"type": "reply",
+			"reply": map[string]interface{}{
+				"id":    btn.ID,
+				"title": btn.Title,
+			},
+		})
 	}
 
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	interactive := map[string]interface{}{
+		"type": "button",
+		"body": map[string]interface{}{
+			"text": bodyText,
+		},
+		"action": map[string]interface{}{
+			"buttons": buttonsList,
+		},
 	}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+const (
+	// maxListRows is the maximum number of rows across all sections of a list message
+	maxListRows = 10
+	// maxListButtonText is the maximum length of the list button label in characters
+	maxListButtonText = 20
+	// maxListRowTitle is the maximum length of a row or section title in characters
+	maxListRowTitle = 24
+	// maxListRowDescription is the maximum length of a row description in characters
+	maxListRowDescription = 72
+)
+
+// ListMessage describes an interactive list message
+type ListMessage struct {
+	ButtonText string // Label of the button that opens the list
+	Header     string // Optional text header
+	Body       string
+	Footer     string // Optional
+	Sections   []ListSection
+}
+
+// ListSection groups rows in a list message
+type ListSection struct {
+	Title string // Required when the list has more than one section
+	Rows  []ListRow
+}
+
+// ListRow is a selectable row in a list message
+type ListRow struct {
+	ID          string
+	Title       string
+	Description string // Optional
+}
+
+// validate checks the list message against WhatsApp's limits
+func (l *ListMessage) validate() error {
+	if l.Body == "" {
+		return fmt.Errorf("body text is required")
+	}
+	if l.ButtonText == "" {
+		return fmt.Errorf("button text is required")
+	}
+	if utf8.RuneCountInString(l.ButtonText) > maxListButtonText {
+		return fmt.Errorf("button text exceeds %d characters", maxListButtonText)
+	}
+	if len(l.Sections) == 0 {
+		return fmt.Errorf("at least one section is required")
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Interactive message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+	rows := 0
+	seen := make(map[string]bool)
+	for i, section := range l.Sections {
+		if len(l.Sections) > 1 && section.Title == "" {
+			return fmt.Errorf("section %d: title is required when there are multiple sections", i+1)
+		}
+		if utf8.RuneCountInString(section.Title) > maxListRowTitle {
+			return fmt.Errorf("section %d: title exceeds %d characters", i+1, maxListRowTitle)
+		}
+		if len(section.Rows) == 0 {
+			return fmt.Errorf("section %d: at least one row is required", i+1)
+		}
+		for j, row := range section.Rows {
+			if row.ID == "" || row.Title == "" {
+				return fmt.Errorf("section %d row %d: ID and title are required", i+1, j+1)
+			}
+			if utf8.RuneCountInString(row.Title) > maxListRowTitle {
+				return fmt.Errorf("section %d row %d: title exceeds %d characters", i+1, j+1, maxListRowTitle)
+			}
+			if utf8.RuneCountInString(row.Description) > maxListRowDescription {
+				return fmt.Errorf("section %d row %d: description exceeds %d characters", i+1, j+1, maxListRowDescription)
+			}
+			if seen[row.ID] {
+				return fmt.Errorf("section %d row %d: duplicate ID %q", i+1, j+1, row.ID)
+			}
+			seen[row.ID] = true
+		}
+		rows += len(section.Rows)
+	}
+	if rows > maxListRows {
+		return fmt.Errorf("maximum %d rows allowed across all sections, got %d", maxListRows, rows)
+	}
+
+	return nil
+}
+
+// SendListMessage sends an interactive list message
+func (c *Client) SendListMessage(ctx context.Context, account *Account, phoneNumber string, list ListMessage, opts ...SendOption) (string, error) {
+	if err := list.validate(); err != nil {
+		return "", err
+	}
+
+	sections := make([]map[string]interface{}, 0, len(list.Sections))
+	for _, section := range list.Sections {
+		rows := make([]map[string]interface{}, 0, len(section.Rows))
+		for _, row := range section.Rows {
+			r := map[string]interface{}{
+				"id":    row.ID,
+				"title": row.Title,
+			}
+			if row.Description != "" {
+				r["description"] = row.Description
+			}
+			rows = append(rows, r)
+		}
+
+		s := map[string]interface{}{"rows": rows}
+		if section.Title != "" {
+			s["title"] = section.Title
+		}
+		sections = append(sections, s)
+	}
+
+	interactive := map[string]interface{}{
+		"type": "list",
+		"body": map[string]interface{}{
+			"text": list.Body,
+		},
+		"action": map[string]interface{}{
+			"button":   list.ButtonText,
+			"sections": sections,
+		},
+	}
+	if list.Header != "" {
+		interactive["header"] = map[string]interface{}{
+			"type": "text",
+			"text": list.Header,
+		}
+	}
+	if list.Footer != "" {
+		interactive["footer"] = map[string]interface{}{
+			"text": list.Footer,
+		}
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+// SendReaction reacts to a previously received or sent message with an emoji.
+// Pass an empty emoji to remove an existing reaction.
+func (c *Client) SendReaction(ctx context.Context, account *Account, phoneNumber, messageID, emoji string, opts ...SendOption) (string, error) {
+	if messageID == "" {
+		return "", fmt.Errorf("message ID is required")
+	}
+	if emoji != "" && !isSingleGrapheme(emoji) {
+		return "", fmt.Errorf("reaction must be a single emoji, got %q", emoji)
+	}
+
+	reaction := map[string]interface{}{
+		"message_id": messageID,
+		"emoji":      emoji,
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "reaction", reaction, opts...)
+}
+
+// isSingleGrapheme approximates whether s is one user-perceived character.
+// It covers the emoji sequences WhatsApp accepts: variation selectors, skin
+// tone modifiers, ZWJ sequences, keycaps, tag sequences and flag pairs.
+func isSingleGrapheme(s string) bool {
+	bases := 0
+	regional := 0
+	joined := false
+	for _, r := range s {
+		switch {
+		case r == 0x200D: // Zero width joiner
+			joined = true
+			continue
+		case r == 0xFE0E || r == 0xFE0F, // Variation selectors
+			r >= 0x1F3FB && r <= 0x1F3FF, // Skin tone modifiers
+			r >= 0xE0020 && r <= 0xE007F, // Tag characters
+			r == 0x20E3:                  // Combining enclosing keycap
+			continue
+		case r >= 0x1F1E6 && r <= 0x1F1FF: // Regional indicators pair up into flags
+			regional++
+			if regional%2 == 0 {
+				continue
+			}
+		}
+
+		if joined {
+			joined = false
+			continue
+		}
+		bases++
+	}
+	return bases == 1 && regional <= 2
 }
 
 // SendCTAURLButton sends an interactive message with a CTA URL button
 // This opens a URL when clicked instead of sending a reply
-func (c *Client) SendCTAURLButton(ctx context.Context, account *Account, phoneNumber, bodyText, buttonText, url string) (string, error) {
+func (c *Client) SendCTAURLButton(ctx context.Context, account *Account, phoneNumber, bodyText, buttonText, url string, opts ...SendOption) (string, error) {
 	if buttonText == "" || url == "" {
 		return "", fmt.Errorf("button text and URL are required")
 	}
@@ -178,55 +549,353 @@ func (c *Client) SendCTAURLButton(ctx context.Context, account *Account, phoneNu
 		},
 	}
 
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "interactive",
-		"interactive":       interactive,
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+// TemplateParam represents a parameter for template message
+type TemplateParam struct {
+	Type          string            `json:"type"`
+	ParameterName string            `json:"parameter_name,omitempty"` // Set for templates using named parameters
+	Text          string            `json:"text,omitempty"`
+	Currency      *TemplateCurrency `json:"currency,omitempty"`
+	DateTime      *TemplateDateTime `json:"date_time,omitempty"`
+	Image         *TemplateMedia    `json:"image,omitempty"`
+	Document      *TemplateMedia    `json:"document,omitempty"`
+	Video         *TemplateMedia    `json:"video,omitempty"`
+	CouponCode    string            `json:"coupon_code,omitempty"` // copy_code buttons
+	Payload       string            `json:"payload,omitempty"`     // quick_reply buttons
+}
+
+// TemplateMedia is the media of an IMAGE, VIDEO or DOCUMENT header, given
+// either as an uploaded media ID or a link
+type TemplateMedia struct {
+	ID       string `json:"id,omitempty"`
+	Link     string `json:"link,omitempty"`
+	Filename string `json:"filename,omitempty"` // Documents only
+}
+
+// TemplateCurrency represents a currency parameter value
+type TemplateCurrency struct {
+	FallbackValue string `json:"fallback_value"`
+	Code          string `json:"code"`        // ISO 4217 currency code
+	Amount1000    int64  `json:"amount_1000"` // Amount multiplied by 1000
+}
+
+// TemplateDateTime represents a date_time parameter value
+type TemplateDateTime struct {
+	FallbackValue string `json:"fallback_value"`
+}
+
+// TextParam returns a positional text parameter
+func TextParam(text string) TemplateParam {
+	return TemplateParam{Type: "text", Text: text}
+}
+
+// NamedTextParam returns a text parameter for templates using named parameters
+func NamedTextParam(name, text string) TemplateParam {
+	return TemplateParam{Type: "text", ParameterName: name, Text: text}
+}
+
+// CurrencyParam returns a currency parameter. amount1000 is the amount multiplied by 1000.
+func CurrencyParam(fallbackValue, code string, amount1000 int64) TemplateParam {
+	return TemplateParam{
+		Type:     "currency",
+		Currency: &TemplateCurrency{FallbackValue: fallbackValue, Code: code, Amount1000: amount1000},
 	}
+}
 
-	apiURL := c.buildMessagesURL(account)
-	c.Log.Debug("Sending CTA URL button message", "phone", phoneNumber, "url", url)
+// DateTimeParam returns a date_time parameter
+func DateTimeParam(fallbackValue string) TemplateParam {
+	return TemplateParam{Type: "date_time", DateTime: &TemplateDateTime{FallbackValue: fallbackValue}}
+}
 
-	respBody, err := c.doRequest(ctx, "POST", apiURL, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send CTA URL button message", "error", err, "phone", phoneNumber)
-		return "", fmt.Errorf("failed to send CTA URL button message: %w", err)
+// ImageParam returns the parameter of an IMAGE header
+func ImageParam(media MediaRef) TemplateParam {
+	return TemplateParam{Type: "image", Image: &TemplateMedia{ID: media.ID, Link: media.Link}}
+}
+
+// VideoParam returns the parameter of a VIDEO header
+func VideoParam(media MediaRef) TemplateParam {
+	return TemplateParam{Type: "video", Video: &TemplateMedia{ID: media.ID, Link: media.Link}}
+}
+
+// DocumentParam returns the parameter of a DOCUMENT header. filename is
+// shown to the recipient and may be empty.
+func DocumentParam(media MediaRef, filename string) TemplateParam {
+	return TemplateParam{Type: "document", Document: &TemplateMedia{ID: media.ID, Link: media.Link, Filename: filename}}
+}
+
+// media returns the media of an image, video or document parameter
+func (p *TemplateParam) media() *TemplateMedia {
+	switch p.Type {
+	case "image":
+		return p.Image
+	case "video":
+		return p.Video
+	case "document":
+		return p.Document
 	}
+	return nil
+}
+
+// TemplateMessageComponent holds the parameters for one component of a template
+type TemplateMessageComponent struct {
+	Type       string          `json:"type"`               // "header", "body" or "button"
+	SubType    string          `json:"sub_type,omitempty"` // Buttons: "url", "copy_code" or "quick_reply"
+	Index      *int            `json:"index,omitempty"`    // Buttons: zero-based position in the template
+	Parameters []TemplateParam `json:"parameters"`
+}
+
+// URLButton returns the component for a dynamic URL button, appending suffix
+// to the URL defined in the template. Authentication templates also use it to
+// pass the one-time password.
+func URLButton(index int, suffix string) TemplateMessageComponent {
+	return TemplateMessageComponent{Type: "button", SubType: "url", Index: &index, Parameters: []TemplateParam{TextParam(suffix)}}
+}
+
+// CopyCodeButton returns the component for a copy-code button with the coupon code to copy
+func CopyCodeButton(index int, code string) TemplateMessageComponent {
+	return TemplateMessageComponent{Type: "button", SubType: "copy_code", Index: &index, Parameters: []TemplateParam{{Type: "coupon_code", CouponCode: code}}}
+}
+
+// QuickReplyButton returns the component for a quick reply button, whose
+// payload is returned in the webhook when the customer taps it
+func QuickReplyButton(index int, payload string) TemplateMessageComponent {
+	return TemplateMessageComponent{Type: "button", SubType: "quick_reply", Index: &index, Parameters: []TemplateParam{{Type: "payload", Payload: payload}}}
+}
+
+// buttonParamTypes is the parameter type required by each button sub type
+var buttonParamTypes = map[string]string{
+	"url":         "text",
+	"copy_code":   "coupon_code",
+	"quick_reply": "payload",
+}
+
+// buttonTypes maps button sub types to the button types they fill in a template
+var buttonTypes = map[string][]string{
+	"url":         {"URL", "OTP"},
+	"copy_code":   {"COPY_CODE", "OTP"},
+	"quick_reply": {"QUICK_REPLY"},
+}
 
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+// TemplateMessage describes a template message with typed component parameters
+type TemplateMessage struct {
+	Name       string
+	Language   string
+	Components []TemplateMessageComponent
+	// Definition is the template as returned by GetTemplates. When set, the
+	// parameters are checked against it before sending.
+	Definition *MetaTemplate
+}
+
+// validate checks the template message before it is sent
+func (t *TemplateMessage) validate() error {
+	if t.Name == "" || t.Language == "" {
+		return fmt.Errorf("template name and language are required")
 	}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+	for i, comp := range t.Components {
+		switch comp.Type {
+		case "header", "body", "button":
+		default:
+			return fmt.Errorf("component %d: unsupported type %q", i+1, comp.Type)
+		}
+
+		// Parameters are either all named or all positional
+		named := 0
+		for j, param := range comp.Parameters {
+			if err := param.validate(); err != nil {
+				return fmt.Errorf("component %d parameter %d: %w", i+1, j+1, err)
+			}
+			if param.ParameterName != "" {
+				named++
+			}
+		}
+		if named > 0 && named != len(comp.Parameters) {
+			return fmt.Errorf("component %d: cannot mix named and positional parameters", i+1)
+		}
+
+		if err := comp.validateMedia(); err != nil {
+			return fmt.Errorf("component %d: %w", i+1, err)
+		}
+		if comp.Type == "button" {
+			if err := comp.validateButton(); err != nil {
+				return fmt.Errorf("component %d: %w", i+1, err)
+			}
+		}
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("CTA URL button message sent", "message_id", messageID, "phone", phoneNumber)
-	return messageID, nil
+	if err := t.validateHeaderFormat(); err != nil {
+		return err
+	}
+	return t.validateButtons()
 }
 
-// TemplateParam represents a parameter for template message
-type TemplateParam struct {
-	Type  string `json:"type"`
-	Text  string `json:"text,omitempty"`
-	Image *struct {
-		Link string `json:"link"`
-	} `json:"image,omitempty"`
-	Document *struct {
-		Link     string `json:"link"`
-		Filename string `json:"filename"`
-	} `json:"document,omitempty"`
-	Video *struct {
-		Link string `json:"link"`
-	} `json:"video,omitempty"`
+// validateButton checks a button component's sub type, index and parameter
+func (c *TemplateMessageComponent) validateButton() error {
+	paramType, ok := buttonParamTypes[c.SubType]
+	if !ok {
+		return fmt.Errorf("unsupported button sub_type %q", c.SubType)
+	}
+	if c.Index == nil || *c.Index < 0 {
+		return fmt.Errorf("button index is required")
+	}
+	if len(c.Parameters) != 1 || c.Parameters[0].Type != paramType {
+		return fmt.Errorf("%s button takes exactly one %s parameter", c.SubType, paramType)
+	}
+	return nil
+}
+
+// validateButtons checks that button indices are unique and, when the
+// template's Definition is known, refer to a button of the matching type
+func (t *TemplateMessage) validateButtons() error {
+	var defined []TemplateButton
+	if t.Definition != nil {
+		for _, comp := range t.Definition.Components {
+			if strings.EqualFold(comp.Type, "buttons") {
+				defined = comp.Buttons
+			}
+		}
+	}
+
+	seen := make(map[int]bool)
+	for _, comp := range t.Components {
+		if comp.Type != "button" {
+			continue
+		}
+		index := *comp.Index
+		if seen[index] {
+			return fmt.Errorf("duplicate parameters for button %d", index)
+		}
+		seen[index] = true
+
+		if t.Definition == nil {
+			continue
+		}
+		if index >= len(defined) {
+			return fmt.Errorf("button index %d out of range: template %s has %d buttons", index, t.Name, len(defined))
+		}
+		if !slices.Contains(buttonTypes[comp.SubType], strings.ToUpper(defined[index].Type)) {
+			return fmt.Errorf("button %d is a %s button, not %s", index, defined[index].Type, comp.SubType)
+		}
+	}
+	return nil
+}
+
+// validateMedia checks that media parameters only appear alone in a header
+func (c *TemplateMessageComponent) validateMedia() error {
+	for _, param := range c.Parameters {
+		if param.media() == nil {
+			continue
+		}
+		if c.Type != "header" {
+			return fmt.Errorf("%s parameter is only allowed in the header", param.Type)
+		}
+		if len(c.Parameters) != 1 {
+			return fmt.Errorf("%s header takes exactly one parameter", param.Type)
+		}
+	}
+	return nil
+}
+
+// validateHeaderFormat checks the header parameter against the header format
+// of the template's Definition, if known
+func (t *TemplateMessage) validateHeaderFormat() error {
+	if t.Definition == nil {
+		return nil
+	}
+
+	format := ""
+	for _, comp := range t.Definition.Components {
+		if strings.EqualFold(comp.Type, "header") {
+			format = strings.ToUpper(comp.Format)
+		}
+	}
+
+	sent := ""
+	for _, comp := range t.Components {
+		if comp.Type == "header" && len(comp.Parameters) > 0 {
+			sent = strings.ToUpper(comp.Parameters[0].Type)
+		}
+	}
+
+	switch format {
+	case "IMAGE", "VIDEO", "DOCUMENT":
+		if sent != format {
+			return fmt.Errorf("template %s requires a %s header parameter", t.Name, format)
+		}
+	default:
+		if sent == "IMAGE" || sent == "VIDEO" || sent == "DOCUMENT" {
+			return fmt.Errorf("template %s has no %s header", t.Name, sent)
+		}
+	}
+	return nil
+}
+
+// validate checks that the parameter carries the value its type requires
+func (p *TemplateParam) validate() error {
+	switch p.Type {
+	case "text":
+		if p.Text == "" {
+			return fmt.Errorf("text parameter requires a value")
+		}
+	case "currency":
+		if p.Currency == nil || p.Currency.Code == "" || p.Currency.FallbackValue == "" {
+			return fmt.Errorf("currency parameter requires a code and fallback value")
+		}
+	case "date_time":
+		if p.DateTime == nil || p.DateTime.FallbackValue == "" {
+			return fmt.Errorf("date_time parameter requires a fallback value")
+		}
+	case "coupon_code":
+		if p.CouponCode == "" {
+			return fmt.Errorf("coupon_code parameter requires a code")
+		}
+	case "payload":
+		if p.Payload == "" {
+			return fmt.Errorf("payload parameter requires a value")
+		}
+	case "image", "document", "video":
+		media := p.media()
+		if media == nil || (media.ID == "") == (media.Link == "") {
+			return fmt.Errorf("%s parameter requires either a media ID or a link", p.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported parameter type %q", p.Type)
+	}
+	return nil
+}
+
+// SendTemplate sends a template message built from typed components.
+// Both positional ({{1}}) and named ({{first_name}}) templates are supported;
+// for named templates set ParameterName on every parameter.
+func (c *Client) SendTemplate(ctx context.Context, account *Account, phoneNumber string, template TemplateMessage, opts ...SendOption) (string, error) {
+	if err := template.validate(); err != nil {
+		return "", err
+	}
+	if err := validatePhoneNumber(phoneNumber); err != nil {
+		return "", err
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "template", template.payload(), opts...)
+}
+
+// payload returns the "template" object of the message
+func (t *TemplateMessage) payload() map[string]interface{} {
+	payload := map[string]interface{}{
+		"name": t.Name,
+		"language": map[string]interface{}{
+			"code": t.Language,
+		},
+	}
+	if len(t.Components) > 0 {
+		payload["components"] = t.Components
+	}
+	return payload
 }
 
 // SendTemplateMessage sends a template message
-func (c *Client) SendTemplateMessage(ctx context.Context, account *Account, phoneNumber, templateName, languageCode string, bodyParams map[string]string) (string, error) {
+func (c *Client) SendTemplateMessage(ctx context.Context, account *Account, phoneNumber, templateName, languageCode string, bodyParams map[string]string, opts ...SendOption) (string, error) {
 	template := map[string]interface{}{
 		"name": templateName,
 		"language": map[string]interface{}{
@@ -272,123 +941,143 @@ func (c *Client) SendTemplateMessage(ctx context.Context, account *Account, phon
 		}
 	}
 
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"to":                phoneNumber,
-		"type":              "template",
-		"template":          template,
-	}
-
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending template message", "phone", phoneNumber, "template", templateName)
+	return c.sendMessage(ctx, account, phoneNumber, "template", template, opts...)
+}
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send template message", "error", err, "phone", phoneNumber, "template", templateName)
-		return "", fmt.Errorf("failed to send template message: %w", err)
+// SendFlowMessage sends an interactive WhatsApp Flow message
+// flowID is the Meta Flow ID, headerText is optional header, bodyText is the message body,
+// ctaText is the button text, flowToken is a unique token for tracking the flow response,
+// and firstScreen is the name of the first screen to navigate to
+func (c *Client) SendFlowMessage(ctx context.Context, account *Account, phoneNumber, flowID, headerText, bodyText, ctaText, flowToken, firstScreen string, opts ...SendOption) (string, error) {
+	if ctaText == "" {
+		ctaText = "Open" // Default CTA text
 	}
-
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	if firstScreen == "" {
+		firstScreen = "FIRST_SCREEN" // Default fallback
 	}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+	// Truncate CTA text to 20 chars (WhatsApp limit)
+	if len(ctaText) > 20 {
+		ctaText = ctaText[:20]
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Template message sent", "message_id", messageID, "phone", phoneNumber, "template", templateName)
-	return messageID, nil
+	return c.SendFlow(ctx, account, phoneNumber, FlowMessage{
+		FlowID:    flowID,
+		FlowToken: flowToken,
+		Header:    headerText,
+		Body:      bodyText,
+		CTA:       ctaText,
+		Screen:    firstScreen,
+	}, opts...)
 }
 
-// SendFlowMessage sends an interactive WhatsApp Flow message
-// flowID is the Meta Flow ID, headerText is optional header, bodyText is the message body,
-// ctaText is the button text, flowToken is a unique token for tracking the flow response,
-// and firstScreen is the name of the first screen to navigate to
-func (c *Client) SendFlowMessage(ctx context.Context, account *Account, phoneNumber, flowID, headerText, bodyText, ctaText, flowToken, firstScreen string) (string, error) {
-	if flowID == "" {
+// FlowMessage is an interactive message that opens a WhatsApp Flow
+type FlowMessage struct {
+	FlowID    string
+	FlowToken string // Echoed back in the flow response; generated when empty
+	Header    string // Optional text header
+	Body      string
+	Footer    string // Optional
+	CTA       string // Button text, at most 20 characters
+	// Screen is the first screen to open, with optional initial Data for it.
+	// When both are empty the flow's data endpoint is asked for the first screen.
+	Screen string
+	Data   map[string]interface{}
+	// Draft sends the flow's draft version, for testing unpublished flows
+	Draft bool
+	// VerifyPublished fetches the flow first and fails with ErrFlowNotPublished
+	// unless it is published. Ignored for drafts.
+	VerifyPublished bool
+}
+
+// ErrFlowNotPublished is returned by SendFlow when FlowMessage.VerifyPublished
+// is set and the flow is not published
+var ErrFlowNotPublished = errors.New("flow is not published")
+
+// maxFlowCTA is the maximum length of a flow message's button text in characters
+const maxFlowCTA = 20
+
+// SendFlow sends an interactive Flow message and returns the message ID
+func (c *Client) SendFlow(ctx context.Context, account *Account, phoneNumber string, flow FlowMessage, opts ...SendOption) (string, error) {
+	if flow.FlowID == "" {
 		return "", fmt.Errorf("flow ID is required")
 	}
-	if bodyText == "" {
+	if flow.Body == "" {
 		return "", fmt.Errorf("body text is required")
 	}
-	if ctaText == "" {
-		ctaText = "Open" // Default CTA text
+	if flow.CTA == "" {
+		return "", fmt.Errorf("CTA text is required")
+	}
+	if utf8.RuneCountInString(flow.CTA) > maxFlowCTA {
+		return "", fmt.Errorf("CTA text exceeds %d characters", maxFlowCTA)
+	}
+
+	if flow.VerifyPublished && !flow.Draft {
+		info, err := c.GetFlow(ctx, account, flow.FlowID)
+		if err != nil {
+			return "", fmt.Errorf("failed to check flow status: %w", err)
+		}
+		if info.Status != "PUBLISHED" {
+			return "", fmt.Errorf("%w: %s is %s", ErrFlowNotPublished, flow.FlowID, info.Status)
+		}
 	}
+
+	flowToken := flow.FlowToken
 	if flowToken == "" {
 		flowToken = fmt.Sprintf("flow_%d", time.Now().UnixNano())
 	}
-	if firstScreen == "" {
-		firstScreen = "FIRST_SCREEN" // Default fallback
-	}
 
-	// Truncate CTA text to 20 chars (WhatsApp limit)
-	if len(ctaText) > 20 {
-		ctaText = ctaText[:20]
+	parameters := map[string]interface{}{
+		"flow_message_version": "3",
+		"flow_token":           flowToken,
+		"flow_id":              flow.FlowID,
+		"flow_cta":             flow.CTA,
+	}
+	if flow.Draft {
+		parameters["mode"] = "draft"
+	}
+	if flow.Screen == "" && len(flow.Data) == 0 {
+		parameters["flow_action"] = "data_exchange"
+	} else {
+		actionPayload := map[string]interface{}{
+			"screen": flow.Screen,
+		}
+		if len(flow.Data) > 0 {
+			actionPayload["data"] = flow.Data
+		}
+		parameters["flow_action"] = "navigate"
+		parameters["flow_action_payload"] = actionPayload
 	}
 
 	interactive := map[string]interface{}{
 		"type": "flow",
 		"body": map[string]interface{}{
-			"text": bodyText,
+			"text": flow.Body,
 		},
 		"action": map[string]interface{}{
-			"name": "flow",
-			"parameters": map[string]interface{}{
-				"flow_message_version": "3",
-				"flow_token":           flowToken,
-				"flow_id":              flowID,
-				"flow_cta":             ctaText,
-				"flow_action":          "navigate",
-				"flow_action_payload": map[string]interface{}{
-					"screen": firstScreen,
-				},
-			},
+			"name":       "flow",
+			"parameters": parameters,
 		},
 	}
 
-	// Add header if provided
-	if headerText != "" {
+	// Add header and footer if provided
+	if flow.Header != "" {
 		interactive["header"] = map[string]interface{}{
 			"type": "text",
-			"text": headerText,
+			"text": flow.Header,
 		}
 	}
-
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"recipient_type":    "individual",
-		"to":                phoneNumber,
-		"type":              "interactive",
-		"interactive":       interactive,
-	}
-
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending flow message", "phone", phoneNumber, "flow_id", flowID)
-
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send flow message", "error", err, "phone", phoneNumber, "flow_id", flowID)
-		return "", fmt.Errorf("failed to send flow message: %w", err)
-	}
-
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+	if flow.Footer != "" {
+		interactive["footer"] = map[string]interface{}{
+			"text": flow.Footer,
+		}
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Flow message sent", "message_id", messageID, "phone", phoneNumber, "flow_id", flowID)
-	return messageID, nil
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
 }
 
 // SendTemplateMessageWithComponents sends a template message with full component control
-func (c *Client) SendTemplateMessageWithComponents(ctx context.Context, account *Account, phoneNumber, templateName, languageCode string, components []map[string]interface{}) (string, error) {
+func (c *Client) SendTemplateMessageWithComponents(ctx context.Context, account *Account, phoneNumber, templateName, languageCode string, components []map[string]interface{}, opts ...SendOption) (string, error) {
 	template := map[string]interface{}{
 		"name": templateName,
 		"language": map[string]interface{}{
@@ -400,32 +1089,141 @@ func (c *Client) SendTemplateMessageWithComponents(ctx context.Context, account
 		template["components"] = components
 	}
 
-	payload := map[string]interface{}{
-		"messaging_product": "whatsapp",
-		"to":                phoneNumber,
-		"type":              "template",
-		"template":          template,
+	return c.sendMessage(ctx, account, phoneNumber, "template", template, opts...)
+}
+
+// sendMessage sends a message of the given type to the messages endpoint and
+// returns the resulting message ID. content becomes the type-specific object,
+// e.g. the "interactive" or "location" field of the payload.
+func (c *Client) sendMessage(ctx context.Context, account *Account, phoneNumber, msgType string, content interface{}, opts ...SendOption) (string, error) {
+	return c.Send(ctx, account, newMessage(phoneNumber, msgType, content, opts))
+}
+
+const (
+	// maxProductSections is the maximum number of sections in a multi-product message
+	maxProductSections = 10
+	// maxProductItems is the maximum number of products across all sections
+	maxProductItems = 30
+)
+
+// ProductSection groups products in a multi-product message
+type ProductSection struct {
+	Title              string   // Required when the message has more than one section
+	ProductRetailerIDs []string // Retailer IDs (SKUs) of the catalog products
+}
+
+// SendProductMessage sends an interactive message showing a single catalog product.
+// bodyText is optional.
+func (c *Client) SendProductMessage(ctx context.Context, account *Account, phoneNumber, catalogID, productRetailerID, bodyText string, opts ...SendOption) (string, error) {
+	if catalogID == "" || productRetailerID == "" {
+		return "", fmt.Errorf("catalog ID and product retailer ID are required")
+	}
+
+	interactive := map[string]interface{}{
+		"type": "product",
+		"action": map[string]interface{}{
+			"catalog_id":          catalogID,
+			"product_retailer_id": productRetailerID,
+		},
+	}
+	if bodyText != "" {
+		interactive["body"] = map[string]interface{}{
+			"text": bodyText,
+		}
 	}
 
-	url := c.buildMessagesURL(account)
-	c.Log.Debug("Sending template message with components", "phone", phoneNumber, "template", templateName)
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
 
-	respBody, err := c.doRequest(ctx, "POST", url, payload, account.AccessToken)
-	if err != nil {
-		c.Log.Error("Failed to send template message", "error", err, "phone", phoneNumber, "template", templateName)
-		return "", fmt.Errorf("failed to send template message: %w", err)
+// SendMultiProductMessage sends an interactive message showing several catalog
+// products grouped into sections. headerText and bodyText are required.
+func (c *Client) SendMultiProductMessage(ctx context.Context, account *Account, phoneNumber, catalogID, headerText, bodyText string, sections []ProductSection, opts ...SendOption) (string, error) {
+	if catalogID == "" {
+		return "", fmt.Errorf("catalog ID is required")
+	}
+	if headerText == "" || bodyText == "" {
+		return "", fmt.Errorf("header text and body text are required")
+	}
+	if len(sections) == 0 {
+		return "", fmt.Errorf("at least one section is required")
+	}
+	if len(sections) > maxProductSections {
+		return "", fmt.Errorf("maximum %d sections allowed", maxProductSections)
 	}
 
-	var resp MetaAPIResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return "", fmt.Errorf("failed to parse response: %w", err)
+	totalItems := 0
+	sectionsList := make([]map[string]interface{}, 0, len(sections))
+	for i, section := range sections {
+		if len(sections) > 1 && section.Title == "" {
+			return "", fmt.Errorf("section %d: title is required when sending multiple sections", i+1)
+		}
+		if len(section.ProductRetailerIDs) == 0 {
+			return "", fmt.Errorf("section %d: at least one product is required", i+1)
+		}
+
+		items := make([]map[string]interface{}, 0, len(section.ProductRetailerIDs))
+		for _, retailerID := range section.ProductRetailerIDs {
+			items = append(items, map[string]interface{}{
+				"product_retailer_id": retailerID,
+			})
+		}
+		totalItems += len(items)
+
+		s := map[string]interface{}{
+			"product_items": items,
+		}
+		if section.Title != "" {
+			s["title"] = section.Title
+		}
+		sectionsList = append(sectionsList, s)
+	}
+	if totalItems > maxProductItems {
+		return "", fmt.Errorf("maximum %d products allowed across all sections, got %d", maxProductItems, totalItems)
 	}
 
-	if len(resp.Messages) == 0 {
-		return "", fmt.Errorf("no message ID in response")
+	interactive := map[string]interface{}{
+		"type": "product_list",
+		"header": map[string]interface{}{
+			"type": "text",
+			"text": headerText,
+		},
+		"body": map[string]interface{}{
+			"text": bodyText,
+		},
+		"action": map[string]interface{}{
+			"catalog_id": catalogID,
+			"sections":   sectionsList,
+		},
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+// SendCatalogMessage sends an interactive message with a button that opens the
+// whole catalog connected to the WABA. bodyText is required; the optional
+// thumbnailRetailerID picks the product shown as the thumbnail, which
+// otherwise is the catalog's first product.
+func (c *Client) SendCatalogMessage(ctx context.Context, account *Account, phoneNumber, bodyText, thumbnailRetailerID string, opts ...SendOption) (string, error) {
+	if bodyText == "" {
+		return "", fmt.Errorf("body text is required")
+	}
+
+	action := map[string]interface{}{
+		"name": "catalog_message",
+	}
+	if thumbnailRetailerID != "" {
+		action["parameters"] = map[string]interface{}{
+			"thumbnail_product_retailer_id": thumbnailRetailerID,
+		}
+	}
+
+	interactive := map[string]interface{}{
+		"type": "catalog_message",
+		"body": map[string]interface{}{
+			"text": bodyText,
+		},
+		"action": action,
 	}
 
-	messageID := resp.Messages[0].ID
-	c.Log.Info("Template message sent", "message_id", messageID, "phone", phoneNumber, "template", templateName)
-	return messageID, nil
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
 }
@@ -1,9 +1,12 @@
 package whatsapp_test
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -461,3 +464,993 @@ func TestClient_SendTemplateMessageWithComponents(t *testing.T) {
 	assert.Len(t, sentComponents, 2)
 }
 
+
+// newMessageCaptureServer returns a test server that decodes each message
+// payload into captured and replies with the given message ID
+func newMessageCaptureServer(t *testing.T, messageID string, captured *map[string]interface{}) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Contains(t, r.URL.Path, "/messages")
+		_ = json.NewDecoder(r.Body).Decode(captured)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"messages": []map[string]string{{"id": messageID}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_SendProductMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.product", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendProductMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", "catalog-1", "SKU-1", "Check this out")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.product", msgID)
+
+	assert.Equal(t, "interactive", body["type"])
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "product", interactive["type"])
+	action := interactive["action"].(map[string]interface{})
+	assert.Equal(t, "catalog-1", action["catalog_id"])
+	assert.Equal(t, "SKU-1", action["product_retailer_id"])
+	assert.Equal(t, "Check this out", interactive["body"].(map[string]interface{})["text"])
+}
+
+func TestClient_SendProductMessage_MissingRetailerID(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	_, err := client.SendProductMessage(testutil.TestContext(t), testAccount(""), "1234567890", "catalog-1", "", "")
+	require.Error(t, err)
+}
+
+func TestClient_SendCatalogMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.catalog", &body)
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	msgID, err := client.SendCatalogMessage(testutil.TestContext(t), account, "1234567890", "Browse our store", "SKU-1")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.catalog", msgID)
+
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "catalog_message", interactive["type"])
+	assert.Equal(t, "Browse our store", interactive["body"].(map[string]interface{})["text"])
+	assert.Equal(t, map[string]interface{}{
+		"name":       "catalog_message",
+		"parameters": map[string]interface{}{"thumbnail_product_retailer_id": "SKU-1"},
+	}, interactive["action"])
+
+	body = nil
+	_, err = client.SendCatalogMessage(testutil.TestContext(t), account, "1234567890", "Browse our store", "")
+	require.NoError(t, err)
+	interactive = body["interactive"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "catalog_message"}, interactive["action"])
+
+	_, err = client.SendCatalogMessage(testutil.TestContext(t), account, "1234567890", "", "SKU-1")
+	require.Error(t, err)
+}
+
+func TestClient_SendMultiProductMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.multi", &body)
+	client := newTestClient(t, server)
+
+	sections := []whatsapp.ProductSection{
+		{Title: "Shirts", ProductRetailerIDs: []string{"SKU-1", "SKU-2"}},
+		{Title: "Pants", ProductRetailerIDs: []string{"SKU-3"}},
+	}
+
+	msgID, err := client.SendMultiProductMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", "catalog-1", "New arrivals", "Browse our picks", sections)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.multi", msgID)
+
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "product_list", interactive["type"])
+	assert.Equal(t, "New arrivals", interactive["header"].(map[string]interface{})["text"])
+	action := interactive["action"].(map[string]interface{})
+	sent := action["sections"].([]interface{})
+	require.Len(t, sent, 2)
+	first := sent[0].(map[string]interface{})
+	assert.Equal(t, "Shirts", first["title"])
+	assert.Len(t, first["product_items"], 2)
+}
+
+func TestClient_SendMultiProductMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	tooMany := make([]string, 31)
+	for i := range tooMany {
+		tooMany[i] = "SKU"
+	}
+
+	tests := []struct {
+		name            string
+		sections        []whatsapp.ProductSection
+		wantErrContains string
+	}{
+		{name: "no sections", sections: nil, wantErrContains: "at least one section"},
+		{
+			name: "missing title with multiple sections",
+			sections: []whatsapp.ProductSection{
+				{ProductRetailerIDs: []string{"SKU-1"}},
+				{Title: "B", ProductRetailerIDs: []string{"SKU-2"}},
+			},
+			wantErrContains: "title is required",
+		},
+		{name: "empty section", sections: []whatsapp.ProductSection{{Title: "A"}}, wantErrContains: "at least one product"},
+		{name: "too many products", sections: []whatsapp.ProductSection{{ProductRetailerIDs: tooMany}}, wantErrContains: "maximum 30 products"},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := client.SendMultiProductMessage(testutil.TestContext(t), testAccount(""), "1234567890", "catalog-1", "Header", "Body", tt.sections)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendTemplate_Positional(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.tpl", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendTemplate(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.TemplateMessage{
+		Name:     "order_update",
+		Language: "en_US",
+		Components: []whatsapp.TemplateMessageComponent{
+			{Type: "header", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("Order #42")}},
+			{Type: "body", Parameters: []whatsapp.TemplateParam{
+				whatsapp.TextParam("John"),
+				whatsapp.CurrencyParam("$12.50", "USD", 12500),
+				whatsapp.DateTimeParam("March 3, 2025"),
+			}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.tpl", msgID)
+
+	assert.Equal(t, "template", body["type"])
+	template := body["template"].(map[string]interface{})
+	assert.Equal(t, "order_update", template["name"])
+	assert.Equal(t, "en_US", template["language"].(map[string]interface{})["code"])
+
+	components := template["components"].([]interface{})
+	require.Len(t, components, 2)
+	params := components[1].(map[string]interface{})["parameters"].([]interface{})
+	require.Len(t, params, 3)
+
+	text := params[0].(map[string]interface{})
+	assert.Equal(t, "text", text["type"])
+	assert.Equal(t, "John", text["text"])
+	assert.NotContains(t, text, "parameter_name")
+
+	currency := params[1].(map[string]interface{})["currency"].(map[string]interface{})
+	assert.Equal(t, "USD", currency["code"])
+	assert.Equal(t, float64(12500), currency["amount_1000"])
+	assert.Equal(t, "$12.50", currency["fallback_value"])
+
+	dateTime := params[2].(map[string]interface{})["date_time"].(map[string]interface{})
+	assert.Equal(t, "March 3, 2025", dateTime["fallback_value"])
+}
+
+func TestClient_SendTemplate_Named(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.named", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendTemplate(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.TemplateMessage{
+		Name:     "welcome",
+		Language: "en",
+		Components: []whatsapp.TemplateMessageComponent{
+			{Type: "body", Parameters: []whatsapp.TemplateParam{
+				whatsapp.NamedTextParam("first_name", "Jane"),
+				whatsapp.NamedTextParam("city", "Pune"),
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	template := body["template"].(map[string]interface{})
+	params := template["components"].([]interface{})[0].(map[string]interface{})["parameters"].([]interface{})
+	first := params[0].(map[string]interface{})
+	assert.Equal(t, "first_name", first["parameter_name"])
+	assert.Equal(t, "Jane", first["text"])
+}
+
+func TestClient_SendTemplate_MediaHeader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		param whatsapp.TemplateParam
+		want  map[string]interface{}
+	}{
+		{"image by ID", whatsapp.ImageParam(whatsapp.MediaRef{ID: "media-1"}), map[string]interface{}{"id": "media-1"}},
+		{"video by link", whatsapp.VideoParam(whatsapp.MediaRef{Link: "https://cdn.example.com/v.mp4"}), map[string]interface{}{"link": "https://cdn.example.com/v.mp4"}},
+		{
+			"document with filename",
+			whatsapp.DocumentParam(whatsapp.MediaRef{Link: "https://cdn.example.com/invoice.pdf"}, "invoice.pdf"),
+			map[string]interface{}{"link": "https://cdn.example.com/invoice.pdf", "filename": "invoice.pdf"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := newMessageCaptureServer(t, "wamid.media", &body)
+			client := newTestClient(t, server)
+
+			_, err := client.SendTemplate(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.TemplateMessage{
+				Name:     "promo",
+				Language: "en",
+				Components: []whatsapp.TemplateMessageComponent{
+					{Type: "header", Parameters: []whatsapp.TemplateParam{tt.param}},
+				},
+				Definition: &whatsapp.MetaTemplate{Name: "promo", Components: []whatsapp.TemplateComponent{
+					{Type: "HEADER", Format: strings.ToUpper(tt.param.Type)},
+				}},
+			})
+			require.NoError(t, err)
+
+			header := body["template"].(map[string]interface{})["components"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "header", header["type"])
+			param := header["parameters"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, tt.param.Type, param["type"])
+			assert.Equal(t, tt.want, param[tt.param.Type])
+		})
+	}
+}
+
+func TestClient_SendTemplate_MediaHeaderValidation(t *testing.T) {
+	t.Parallel()
+
+	imageHeader := []whatsapp.TemplateMessageComponent{
+		{Type: "header", Parameters: []whatsapp.TemplateParam{whatsapp.ImageParam(whatsapp.MediaRef{ID: "media-1"})}},
+	}
+	tests := []struct {
+		name            string
+		template        whatsapp.TemplateMessage
+		wantErrContains string
+	}{
+		{
+			name: "media without ID or link",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "header", Parameters: []whatsapp.TemplateParam{whatsapp.ImageParam(whatsapp.MediaRef{})}},
+			}},
+			wantErrContains: "either a media ID or a link",
+		},
+		{
+			name: "media with both ID and link",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "header", Parameters: []whatsapp.TemplateParam{whatsapp.VideoParam(whatsapp.MediaRef{ID: "m", Link: "https://x"})}},
+			}},
+			wantErrContains: "either a media ID or a link",
+		},
+		{
+			name: "media in body",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "body", Parameters: []whatsapp.TemplateParam{whatsapp.ImageParam(whatsapp.MediaRef{ID: "m"})}},
+			}},
+			wantErrContains: "only allowed in the header",
+		},
+		{
+			name: "header format mismatch",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: imageHeader,
+				Definition: &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{{Type: "HEADER", Format: "VIDEO"}}}},
+			wantErrContains: "requires a VIDEO header",
+		},
+		{
+			name: "missing required media header",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en",
+				Definition: &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{{Type: "HEADER", Format: "DOCUMENT"}}}},
+			wantErrContains: "requires a DOCUMENT header",
+		},
+		{
+			name: "media header on text template",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: imageHeader,
+				Definition: &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{{Type: "BODY", Text: "Hi"}}}},
+			wantErrContains: "has no IMAGE header",
+		},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendTemplate(testutil.TestContext(t), testAccount(""), "1234567890", tt.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendTemplate_Buttons(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.buttons", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendTemplate(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.TemplateMessage{
+		Name:     "spring_sale",
+		Language: "en",
+		Components: []whatsapp.TemplateMessageComponent{
+			whatsapp.URLButton(0, "SALE25"),
+			whatsapp.CopyCodeButton(1, "25OFF"),
+			whatsapp.QuickReplyButton(2, "UNSUBSCRIBE"),
+		},
+		Definition: &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{
+			{Type: "BUTTONS", Buttons: []whatsapp.TemplateButton{
+				{Type: "URL", Text: "Shop now", URL: "https://shop.example.com/{{1}}"},
+				{Type: "COPY_CODE", Text: "Copy offer code"},
+				{Type: "QUICK_REPLY", Text: "Stop promotions"},
+			}},
+		}},
+	})
+	require.NoError(t, err)
+
+	components := body["template"].(map[string]interface{})["components"].([]interface{})
+	require.Len(t, components, 3)
+
+	url := components[0].(map[string]interface{})
+	assert.Equal(t, "button", url["type"])
+	assert.Equal(t, "url", url["sub_type"])
+	assert.Equal(t, float64(0), url["index"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "text", "text": "SALE25"}}, url["parameters"])
+
+	copyCode := components[1].(map[string]interface{})
+	assert.Equal(t, "copy_code", copyCode["sub_type"])
+	assert.Equal(t, float64(1), copyCode["index"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "coupon_code", "coupon_code": "25OFF"}}, copyCode["parameters"])
+
+	quickReply := components[2].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"type": "payload", "payload": "UNSUBSCRIBE"}}, quickReply["parameters"])
+}
+
+func TestClient_SendTemplate_AuthenticationOTP(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.otp", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendTemplate(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.TemplateMessage{
+		Name:     "login_code",
+		Language: "en",
+		Components: []whatsapp.TemplateMessageComponent{
+			{Type: "body", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("482913")}},
+			whatsapp.URLButton(0, "482913"),
+		},
+		Definition: &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{
+			{Type: "BODY"},
+			{Type: "BUTTONS", Buttons: []whatsapp.TemplateButton{{Type: "OTP", Text: "Copy code"}}},
+		}},
+	})
+	require.NoError(t, err)
+
+	components := body["template"].(map[string]interface{})["components"].([]interface{})
+	body0 := components[0].(map[string]interface{})
+	assert.NotContains(t, body0, "sub_type")
+	assert.NotContains(t, body0, "index")
+}
+
+func TestClient_SendTemplate_ButtonValidation(t *testing.T) {
+	t.Parallel()
+
+	definition := &whatsapp.MetaTemplate{Components: []whatsapp.TemplateComponent{
+		{Type: "BUTTONS", Buttons: []whatsapp.TemplateButton{{Type: "URL"}, {Type: "QUICK_REPLY"}}},
+	}}
+	tests := []struct {
+		name            string
+		components      []whatsapp.TemplateMessageComponent
+		definition      *whatsapp.MetaTemplate
+		wantErrContains string
+	}{
+		{"missing sub_type", []whatsapp.TemplateMessageComponent{{Type: "button", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("x")}}}, nil, "unsupported button sub_type"},
+		{"missing index", []whatsapp.TemplateMessageComponent{{Type: "button", SubType: "url", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("x")}}}, nil, "button index is required"},
+		{"wrong parameter type", []whatsapp.TemplateMessageComponent{{Type: "button", SubType: "copy_code", Index: new(int), Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("x")}}}, nil, "copy_code button takes exactly one coupon_code parameter"},
+		{"empty coupon code", []whatsapp.TemplateMessageComponent{whatsapp.CopyCodeButton(0, "")}, nil, "coupon_code parameter requires a code"},
+		{"duplicate index", []whatsapp.TemplateMessageComponent{whatsapp.URLButton(0, "a"), whatsapp.CopyCodeButton(0, "b")}, nil, "duplicate parameters for button 0"},
+		{"index out of range", []whatsapp.TemplateMessageComponent{whatsapp.URLButton(2, "a")}, definition, "button index 2 out of range"},
+		{"button type mismatch", []whatsapp.TemplateMessageComponent{whatsapp.CopyCodeButton(1, "a")}, definition, "button 1 is a QUICK_REPLY button, not copy_code"},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendTemplate(testutil.TestContext(t), testAccount(""), "1234567890", whatsapp.TemplateMessage{
+				Name: "t", Language: "en", Components: tt.components, Definition: tt.definition,
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendTemplate_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		template        whatsapp.TemplateMessage
+		wantErrContains string
+	}{
+		{name: "missing name", template: whatsapp.TemplateMessage{Language: "en"}, wantErrContains: "name and language are required"},
+		{
+			name: "mixed named and positional",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "body", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("a"), whatsapp.NamedTextParam("b", "c")}},
+			}},
+			wantErrContains: "cannot mix named and positional",
+		},
+		{
+			name: "unsupported component",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "footer"},
+			}},
+			wantErrContains: "unsupported type",
+		},
+		{
+			name: "currency without code",
+			template: whatsapp.TemplateMessage{Name: "t", Language: "en", Components: []whatsapp.TemplateMessageComponent{
+				{Type: "body", Parameters: []whatsapp.TemplateParam{whatsapp.CurrencyParam("$1", "", 1000)}},
+			}},
+			wantErrContains: "currency parameter requires",
+		},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendTemplate(testutil.TestContext(t), testAccount(""), "1234567890", tt.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendButtonMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.buttons", &body)
+	client := newTestClient(t, server)
+
+	buttons := []whatsapp.Button{
+		{ID: "yes", Title: "Yes"},
+		{ID: "no", Title: "No"},
+		{ID: "later", Title: "Remind me tomorrow"},
+	}
+	msgID, err := client.SendButtonMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", "Confirm your order?", buttons)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.buttons", msgID)
+
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "button", interactive["type"])
+	sent := interactive["action"].(map[string]interface{})["buttons"].([]interface{})
+	require.Len(t, sent, 3)
+	reply := sent[2].(map[string]interface{})["reply"].(map[string]interface{})
+	assert.Equal(t, "later", reply["id"])
+	assert.Equal(t, "Remind me tomorrow", reply["title"])
+}
+
+func TestClient_SendButtonMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		buttons         []whatsapp.Button
+		wantErrContains string
+	}{
+		{name: "no buttons", buttons: nil, wantErrContains: "between 1 and 3"},
+		{
+			name:            "too many buttons",
+			buttons:         []whatsapp.Button{{ID: "1", Title: "a"}, {ID: "2", Title: "b"}, {ID: "3", Title: "c"}, {ID: "4", Title: "d"}},
+			wantErrContains: "between 1 and 3",
+		},
+		{name: "title too long", buttons: []whatsapp.Button{{ID: "1", Title: "This title is far too long"}}, wantErrContains: "exceeds 20 characters"},
+		{name: "missing ID", buttons: []whatsapp.Button{{Title: "Yes"}}, wantErrContains: "ID and title are required"},
+		{name: "duplicate ID", buttons: []whatsapp.Button{{ID: "1", Title: "a"}, {ID: "1", Title: "b"}}, wantErrContains: "duplicate ID"},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendButtonMessage(testutil.TestContext(t), testAccount(""), "1234567890", "Pick one", tt.buttons)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendButtonMessage_MultibyteTitle(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.emoji", &body)
+	client := newTestClient(t, server)
+
+	// 20 characters but more than 20 bytes
+	title := "Sí, confirmo pedido✅"
+	_, err := client.SendButtonMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", "Confirm?", []whatsapp.Button{{ID: "ok", Title: title}})
+	require.NoError(t, err)
+}
+
+func TestClient_SendListMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.list", &body)
+	client := newTestClient(t, server)
+
+	list := whatsapp.ListMessage{
+		ButtonText: "View menu",
+		Header:     "Our menu",
+		Body:       "Pick a dish",
+		Footer:     "Prices include tax",
+		Sections: []whatsapp.ListSection{
+			{Title: "Mains", Rows: []whatsapp.ListRow{
+				{ID: "pasta", Title: "Pasta", Description: "Fresh tagliatelle"},
+				{ID: "pizza", Title: "Pizza"},
+			}},
+			{Title: "Desserts", Rows: []whatsapp.ListRow{{ID: "tiramisu", Title: "Tiramisu"}}},
+		},
+	}
+
+	msgID, err := client.SendListMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", list)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.list", msgID)
+
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "list", interactive["type"])
+	assert.Equal(t, "Our menu", interactive["header"].(map[string]interface{})["text"])
+	assert.Equal(t, "Prices include tax", interactive["footer"].(map[string]interface{})["text"])
+
+	action := interactive["action"].(map[string]interface{})
+	assert.Equal(t, "View menu", action["button"])
+	sections := action["sections"].([]interface{})
+	require.Len(t, sections, 2)
+	rows := sections[0].(map[string]interface{})["rows"].([]interface{})
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Fresh tagliatelle", rows[0].(map[string]interface{})["description"])
+	assert.NotContains(t, rows[1], "description")
+}
+
+func TestClient_SendListMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	manyRows := make([]whatsapp.ListRow, 11)
+	for i := range manyRows {
+		manyRows[i] = whatsapp.ListRow{ID: fmt.Sprintf("row-%d", i), Title: "Row"}
+	}
+
+	tests := []struct {
+		name            string
+		list            whatsapp.ListMessage
+		wantErrContains string
+	}{
+		{name: "no sections", list: whatsapp.ListMessage{ButtonText: "Menu", Body: "Pick"}, wantErrContains: "at least one section"},
+		{name: "missing button", list: whatsapp.ListMessage{Body: "Pick"}, wantErrContains: "button text is required"},
+		{
+			name:            "too many rows",
+			list:            whatsapp.ListMessage{ButtonText: "Menu", Body: "Pick", Sections: []whatsapp.ListSection{{Rows: manyRows}}},
+			wantErrContains: "maximum 10 rows",
+		},
+		{
+			name: "missing section title",
+			list: whatsapp.ListMessage{ButtonText: "Menu", Body: "Pick", Sections: []whatsapp.ListSection{
+				{Title: "A", Rows: []whatsapp.ListRow{{ID: "1", Title: "One"}}},
+				{Rows: []whatsapp.ListRow{{ID: "2", Title: "Two"}}},
+			}},
+			wantErrContains: "title is required",
+		},
+		{
+			name: "row title too long",
+			list: whatsapp.ListMessage{ButtonText: "Menu", Body: "Pick", Sections: []whatsapp.ListSection{
+				{Rows: []whatsapp.ListRow{{ID: "1", Title: "A row title that is very long"}}},
+			}},
+			wantErrContains: "exceeds 24 characters",
+		},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendListMessage(testutil.TestContext(t), testAccount(""), "1234567890", tt.list)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_SendReaction(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.reaction", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendReaction(testutil.TestContext(t), testAccount(server.URL), "1234567890", "wamid.original", "👍")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.reaction", msgID)
+
+	assert.Equal(t, "reaction", body["type"])
+	reaction := body["reaction"].(map[string]interface{})
+	assert.Equal(t, "wamid.original", reaction["message_id"])
+	assert.Equal(t, "👍", reaction["emoji"])
+}
+
+func TestClient_SendReaction_Remove(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.reaction", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendReaction(testutil.TestContext(t), testAccount(server.URL), "1234567890", "wamid.original", "")
+	require.NoError(t, err)
+
+	reaction := body["reaction"].(map[string]interface{})
+	assert.Equal(t, "", reaction["emoji"])
+}
+
+func TestClient_SendReaction_EmojiValidation(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.reaction", &body)
+	client := newTestClient(t, server)
+	ctx := testutil.TestContext(t)
+	account := testAccount(server.URL)
+
+	for _, emoji := range []string{"❤️", "👍🏽", "👨‍👩‍👧", "🇮🇳", "1️⃣"} {
+		_, err := client.SendReaction(ctx, account, "1234567890", "wamid.original", emoji)
+		assert.NoError(t, err, "emoji %q", emoji)
+	}
+
+	for _, emoji := range []string{"👍👍", "ok", "🇮🇳🇺🇸"} {
+		_, err := client.SendReaction(ctx, account, "1234567890", "wamid.original", emoji)
+		assert.Error(t, err, "emoji %q", emoji)
+	}
+
+	_, err := client.SendReaction(ctx, account, "1234567890", "", "👍")
+	assert.Error(t, err)
+}
+
+func TestClient_SendLocationMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.location", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendLocationMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", 18.5204, 73.8567, "Pickup point", "FC Road, Pune")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.location", msgID)
+
+	assert.Equal(t, "location", body["type"])
+	location := body["location"].(map[string]interface{})
+	assert.Equal(t, 18.5204, location["latitude"])
+	assert.Equal(t, 73.8567, location["longitude"])
+	assert.Equal(t, "Pickup point", location["name"])
+	assert.Equal(t, "FC Road, Pune", location["address"])
+}
+
+func TestClient_SendLocationMessage_InvalidCoordinates(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	ctx := testutil.TestContext(t)
+
+	_, err := client.SendLocationMessage(ctx, testAccount(""), "1234567890", 91, 0, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "latitude")
+
+	_, err = client.SendLocationMessage(ctx, testAccount(""), "1234567890", 0, -180.5, "", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "longitude")
+}
+
+func TestClient_SendContactsMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.contacts", &body)
+	client := newTestClient(t, server)
+
+	contacts := []whatsapp.Contact{{
+		Name:   whatsapp.ContactName{FormattedName: "Acme Support", FirstName: "Acme"},
+		Phones: []whatsapp.ContactPhone{{Phone: "+15551234567", Type: "WORK", WaID: "15551234567"}},
+		Emails: []whatsapp.ContactEmail{{Email: "support@acme.test", Type: "WORK"}},
+		Org:    &whatsapp.ContactOrg{Company: "Acme", Title: "Support"},
+		Addresses: []whatsapp.ContactAddress{{
+			Street: "1 Main St", City: "Springfield", CountryCode: "US", Type: "WORK",
+		}},
+	}}
+
+	msgID, err := client.SendContactsMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", contacts)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.contacts", msgID)
+
+	assert.Equal(t, "contacts", body["type"])
+	sent := body["contacts"].([]interface{})
+	require.Len(t, sent, 1)
+	contact := sent[0].(map[string]interface{})
+	assert.Equal(t, "Acme Support", contact["name"].(map[string]interface{})["formatted_name"])
+	phone := contact["phones"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "15551234567", phone["wa_id"])
+	assert.Equal(t, "Acme", contact["org"].(map[string]interface{})["company"])
+	address := contact["addresses"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "Springfield", address["city"])
+	assert.NotContains(t, contact, "urls")
+}
+
+func TestClient_SendContactsMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	ctx := testutil.TestContext(t)
+
+	_, err := client.SendContactsMessage(ctx, testAccount(""), "1234567890", nil)
+	require.Error(t, err)
+
+	_, err = client.SendContactsMessage(ctx, testAccount(""), "1234567890", []whatsapp.Contact{{Name: whatsapp.ContactName{FirstName: "Jane"}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "formatted name is required")
+}
+
+func TestClient_SendDocument(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.invoice", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendDocument(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.DocumentMessage{
+		Link:     "https://files.example.com/invoice-42.pdf",
+		Filename: "invoice-42.pdf",
+		Caption:  "Your invoice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.invoice", msgID)
+
+	assert.Equal(t, "document", body["type"])
+	assert.Equal(t, map[string]interface{}{
+		"link":     "https://files.example.com/invoice-42.pdf",
+		"filename": "invoice-42.pdf",
+		"caption":  "Your invoice",
+	}, body["document"])
+}
+
+func TestClient_SendDocument_MediaID(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.doc", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendDocument(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.DocumentMessage{
+		MediaID: "media-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"id": "media-1"}, body["document"])
+}
+
+func TestClient_SendDocument_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	ctx := testutil.TestContext(t)
+
+	_, err := client.SendDocument(ctx, testAccount(""), "1234567890", whatsapp.DocumentMessage{Filename: "a.pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "media ID or link is required")
+
+	_, err = client.SendDocument(ctx, testAccount(""), "1234567890", whatsapp.DocumentMessage{MediaID: "m", Link: "https://x.test/a.pdf"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mutually exclusive")
+}
+
+func TestClient_SendImageAndVideo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		msgType string
+		send    func(c *whatsapp.Client, ctx context.Context, account *whatsapp.Account) (string, error)
+		want    map[string]interface{}
+	}{
+		{
+			name:    "image by link",
+			msgType: "image",
+			send: func(c *whatsapp.Client, ctx context.Context, account *whatsapp.Account) (string, error) {
+				return c.SendImage(ctx, account, "1234567890", whatsapp.MediaRef{Link: "https://cdn.example.com/a.jpg"}, "Sale!")
+			},
+			want: map[string]interface{}{"link": "https://cdn.example.com/a.jpg", "caption": "Sale!"},
+		},
+		{
+			name:    "video by id without caption",
+			msgType: "video",
+			send: func(c *whatsapp.Client, ctx context.Context, account *whatsapp.Account) (string, error) {
+				return c.SendVideo(ctx, account, "1234567890", whatsapp.MediaRef{ID: "media-9"}, "")
+			},
+			want: map[string]interface{}{"id": "media-9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := newMessageCaptureServer(t, "wamid.media", &body)
+			client := newTestClient(t, server)
+
+			msgID, err := tt.send(client, testutil.TestContext(t), testAccount(server.URL))
+			require.NoError(t, err)
+			assert.Equal(t, "wamid.media", msgID)
+			assert.Equal(t, tt.msgType, body["type"])
+			assert.Equal(t, tt.want, body[tt.msgType])
+		})
+	}
+}
+
+func TestClient_SendImage_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	_, err := client.SendImage(testutil.TestContext(t), testAccount(""), "1234567890", whatsapp.MediaRef{}, "caption")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "media ID or link is required")
+}
+
+func TestClient_SendAudioAndSticker(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.audio", &body)
+	client := newTestClient(t, server)
+	ctx := testutil.TestContext(t)
+
+	msgID, err := client.SendAudio(ctx, testAccount(server.URL), "1234567890", whatsapp.MediaRef{ID: "media-1", MimeType: "audio/ogg; codecs=opus"})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.audio", msgID)
+	assert.Equal(t, "audio", body["type"])
+	assert.Equal(t, map[string]interface{}{"id": "media-1"}, body["audio"])
+
+	_, err = client.SendSticker(ctx, testAccount(server.URL), "1234567890", whatsapp.MediaRef{Link: "https://cdn.example.com/s.webp", MimeType: "image/webp"})
+	require.NoError(t, err)
+	assert.Equal(t, "sticker", body["type"])
+	assert.Equal(t, map[string]interface{}{"link": "https://cdn.example.com/s.webp"}, body["sticker"])
+}
+
+func TestClient_SendAudioAndSticker_MimeValidation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	ctx := testutil.TestContext(t)
+
+	_, err := client.SendAudio(ctx, testAccount(""), "1234567890", whatsapp.MediaRef{ID: "m", MimeType: "audio/wav"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported audio type")
+
+	_, err = client.SendSticker(ctx, testAccount(""), "1234567890", whatsapp.MediaRef{ID: "m", MimeType: "image/png"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "image/webp")
+}
+
+func TestClient_SendFlow(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.flow", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendFlow(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.FlowMessage{
+		FlowID:    "flow-1",
+		FlowToken: "token-1",
+		Header:    "Book a table",
+		Body:      "Pick a time that suits you",
+		Footer:    "Takes a minute",
+		CTA:       "Book now",
+		Screen:    "BOOKING",
+		Data:      map[string]interface{}{"party_size": 2},
+		Draft:     true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.flow", msgID)
+
+	interactive := body["interactive"].(map[string]interface{})
+	assert.Equal(t, "flow", interactive["type"])
+	assert.Equal(t, "Takes a minute", interactive["footer"].(map[string]interface{})["text"])
+
+	params := interactive["action"].(map[string]interface{})["parameters"].(map[string]interface{})
+	assert.Equal(t, "flow-1", params["flow_id"])
+	assert.Equal(t, "token-1", params["flow_token"])
+	assert.Equal(t, "Book now", params["flow_cta"])
+	assert.Equal(t, "draft", params["mode"])
+	assert.Equal(t, "navigate", params["flow_action"])
+	payload := params["flow_action_payload"].(map[string]interface{})
+	assert.Equal(t, "BOOKING", payload["screen"])
+	assert.Equal(t, float64(2), payload["data"].(map[string]interface{})["party_size"])
+}
+
+func TestClient_SendFlow_DataExchange(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.flow", &body)
+	client := newTestClient(t, server)
+
+	_, err := client.SendFlow(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.FlowMessage{
+		FlowID: "flow-1",
+		Body:   "Continue",
+		CTA:    "Start",
+	})
+	require.NoError(t, err)
+
+	params := body["interactive"].(map[string]interface{})["action"].(map[string]interface{})["parameters"].(map[string]interface{})
+	assert.Equal(t, "data_exchange", params["flow_action"])
+	assert.NotContains(t, params, "flow_action_payload")
+	assert.NotContains(t, params, "mode")
+	assert.NotEmpty(t, params["flow_token"])
+}
+
+func TestClient_SendFlow_VerifyPublished(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "unpublished flow should not be sent")
+		assert.Equal(t, "/v21.0/flow-1", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"flow-1","name":"Booking","status":"DRAFT"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.SendFlow(testutil.TestContext(t), testAccount(server.URL), "1234567890", whatsapp.FlowMessage{
+		FlowID:          "flow-1",
+		Body:            "Book a table",
+		CTA:             "Book",
+		Screen:          "BOOKING",
+		VerifyPublished: true,
+	})
+	require.ErrorIs(t, err, whatsapp.ErrFlowNotPublished)
+}
+
+func TestClient_SendFlow_Validation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid flow message should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	tests := []whatsapp.FlowMessage{
+		{Body: "Body", CTA: "Open"},
+		{FlowID: "flow-1", CTA: "Open"},
+		{FlowID: "flow-1", Body: "Body"},
+		{FlowID: "flow-1", Body: "Body", CTA: "This button text is too long"},
+	}
+	for _, flow := range tests {
+		_, err := client.SendFlow(testutil.TestContext(t), testAccount(server.URL), "1234567890", flow)
+		assert.Error(t, err)
+	}
+}
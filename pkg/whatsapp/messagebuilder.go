@@ -0,0 +1,250 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+)
+
+// Message is a message ready to be sent with Client.Send. It is usually
+// built with NewMessage; its JSON encoding is the messages endpoint payload.
+type Message struct {
+	To           string
+	ReplyTo      string      // ID of the message being replied to, if any
+	CallbackData string      // Echoed back on the message's status webhooks, if set
+	Type         string      // e.g. "text", "template", "image"
+	Content      interface{} // The type-specific object, e.g. the "text" field
+}
+
+// MarshalJSON implements json.Marshaler
+func (m Message) MarshalJSON() ([]byte, error) {
+	payload := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"recipient_type":    "individual",
+		"to":                m.To,
+		"type":              m.Type,
+		m.Type:              m.Content,
+	}
+	if m.ReplyTo != "" {
+		payload["context"] = map[string]interface{}{
+			"message_id": m.ReplyTo,
+		}
+	}
+	if m.CallbackData != "" {
+		payload["biz_opaque_callback_data"] = m.CallbackData
+	}
+	return json.Marshal(payload)
+}
+
+// SendOption customizes a message sent by one of the Client.Send* methods
+type SendOption func(*Message)
+
+// WithReplyTo sends the message as a reply quoting the message with the given ID.
+// An empty ID leaves the message unthreaded.
+func WithReplyTo(messageID string) SendOption {
+	return func(m *Message) {
+		m.ReplyTo = messageID
+	}
+}
+
+// WithPreviewURL renders a preview of the first link in a text message's body.
+// It has no effect on other message types.
+func WithPreviewURL() SendOption {
+	return func(m *Message) {
+		if text, ok := m.Content.(map[string]interface{}); ok && m.Type == "text" {
+			text["preview_url"] = true
+		}
+	}
+}
+
+// maxCallbackData is the maximum length of biz_opaque_callback_data
+const maxCallbackData = 512
+
+// WithCallbackData attaches an opaque string, such as an internal tracking ID,
+// that Meta returns as StatusUpdate.CallbackData on the message's status
+// webhooks. It may be at most 512 characters.
+func WithCallbackData(data string) SendOption {
+	return func(m *Message) {
+		m.CallbackData = data
+	}
+}
+
+// validateCallbackData checks the length of a message's callback data
+func validateCallbackData(data string) error {
+	if n := utf8.RuneCountInString(data); n > maxCallbackData {
+		return fmt.Errorf("callback data is %d characters, exceeds maximum of %d", n, maxCallbackData)
+	}
+	return nil
+}
+
+// newMessage builds a message and applies the send options to it
+func newMessage(phoneNumber, msgType string, content interface{}, opts []SendOption) *Message {
+	m := &Message{To: phoneNumber, Type: msgType, Content: content}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// MessageBuilder composes a Message fluently, e.g.
+//
+//	msg, err := whatsapp.NewMessage().To(phone).ReplyTo(inboundID).Text("Thanks!").Build()
+//
+// The first error, such as setting two contents, is reported by Build.
+type MessageBuilder struct {
+	msg Message
+	err error
+}
+
+// NewMessage starts building a message
+func NewMessage() *MessageBuilder {
+	return &MessageBuilder{}
+}
+
+// To sets the recipient's phone number
+func (b *MessageBuilder) To(phoneNumber string) *MessageBuilder {
+	b.msg.To = phoneNumber
+	return b
+}
+
+// ReplyTo quotes the message with the given ID, threading the new message as a reply
+func (b *MessageBuilder) ReplyTo(messageID string) *MessageBuilder {
+	b.msg.ReplyTo = messageID
+	return b
+}
+
+// CallbackData attaches an opaque string that is echoed back on the message's
+// status webhooks, as with WithCallbackData
+func (b *MessageBuilder) CallbackData(data string) *MessageBuilder {
+	if err := validateCallbackData(data); err != nil {
+		return b.fail(err)
+	}
+	b.msg.CallbackData = data
+	return b
+}
+
+// Text sets a text body. Links are previewed when previewURL is true.
+func (b *MessageBuilder) Text(body string, previewURL bool) *MessageBuilder {
+	if body == "" {
+		return b.fail(fmt.Errorf("text body is required"))
+	}
+	if err := validateTextBody(body); err != nil {
+		return b.fail(err)
+	}
+	return b.content("text", map[string]interface{}{
+		"preview_url": previewURL,
+		"body":        body,
+	})
+}
+
+// Template sets a template message
+func (b *MessageBuilder) Template(template TemplateMessage) *MessageBuilder {
+	if err := template.validate(); err != nil {
+		return b.fail(err)
+	}
+	return b.content("template", template.payload())
+}
+
+// Image sets an image with an optional caption
+func (b *MessageBuilder) Image(media MediaRef, caption string) *MessageBuilder {
+	return b.media("image", media, map[string]string{"caption": caption})
+}
+
+// Video sets a video with an optional caption
+func (b *MessageBuilder) Video(media MediaRef, caption string) *MessageBuilder {
+	return b.media("video", media, map[string]string{"caption": caption})
+}
+
+// Document sets a document
+func (b *MessageBuilder) Document(doc DocumentMessage) *MessageBuilder {
+	return b.media("document", MediaRef{ID: doc.MediaID, Link: doc.Link}, map[string]string{"filename": doc.Filename, "caption": doc.Caption})
+}
+
+// Audio sets an audio file or voice note
+func (b *MessageBuilder) Audio(media MediaRef) *MessageBuilder {
+	if media.MimeType != "" && !audioMimeTypes[baseMimeType(media.MimeType)] {
+		return b.fail(fmt.Errorf("unsupported audio type %q", media.MimeType))
+	}
+	return b.media("audio", media, nil)
+}
+
+// Build returns the message, or the first error recorded while building it
+func (b *MessageBuilder) Build() (*Message, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if b.msg.Type == "" {
+		return nil, fmt.Errorf("message content is required")
+	}
+	if err := validatePhoneNumber(b.msg.To); err != nil {
+		return nil, err
+	}
+	msg := b.msg
+	return &msg, nil
+}
+
+// media sets a media content built from a media reference and extra fields
+func (b *MessageBuilder) media(msgType string, media MediaRef, extra map[string]string) *MessageBuilder {
+	object, err := buildMediaObject(media)
+	if err != nil {
+		return b.fail(err)
+	}
+	for key, value := range extra {
+		if value != "" {
+			object[key] = value
+		}
+	}
+	return b.content(msgType, object)
+}
+
+// content sets the message type and its content, which may only be set once
+func (b *MessageBuilder) content(msgType string, content interface{}) *MessageBuilder {
+	if b.msg.Type != "" {
+		return b.fail(fmt.Errorf("message content already set to %s", b.msg.Type))
+	}
+	b.msg.Type = msgType
+	b.msg.Content = content
+	return b
+}
+
+// fail records the first error encountered while building
+func (b *MessageBuilder) fail(err error) *MessageBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Send sends a message and returns its ID
+func (c *Client) Send(ctx context.Context, account *Account, m *Message) (string, error) {
+	if err := validatePhoneNumber(m.To); err != nil {
+		return "", err
+	}
+	if err := validateCallbackData(m.CallbackData); err != nil {
+		return "", err
+	}
+
+	url := c.buildMessagesURL(account)
+	c.Log.Debug("Sending message", "type", m.Type, "phone", m.To)
+
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, m, account)
+	if err != nil {
+		c.Log.Error("Failed to send message", "error", err, "type", m.Type, "phone", m.To)
+		return "", fmt.Errorf("failed to send %s message: %w", m.Type, err)
+	}
+
+	var resp MetaAPIResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(resp.Messages) == 0 {
+		return "", fmt.Errorf("no message ID in response")
+	}
+
+	messageID := resp.Messages[0].ID
+	c.Log.Info("Message sent", "type", m.Type, "message_id", messageID, "phone", m.To)
+	return messageID, nil
+}
@@ -0,0 +1,186 @@
+package whatsapp_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageBuilder_TextReply(t *testing.T) {
+	t.Parallel()
+
+	msg, err := whatsapp.NewMessage().To("1234567890").ReplyTo("wamid.inbound").Text("Thanks, on it!", false).Build()
+	require.NoError(t, err)
+
+	var body map[string]interface{}
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &body))
+
+	assert.Equal(t, "whatsapp", body["messaging_product"])
+	assert.Equal(t, "1234567890", body["to"])
+	assert.Equal(t, "text", body["type"])
+	assert.Equal(t, map[string]interface{}{"message_id": "wamid.inbound"}, body["context"])
+	assert.Equal(t, map[string]interface{}{"body": "Thanks, on it!", "preview_url": false}, body["text"])
+}
+
+func TestMessageBuilder_Validation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		builder         *whatsapp.MessageBuilder
+		wantErrContains string
+	}{
+		{"no content", whatsapp.NewMessage().To("1234567890"), "message content is required"},
+		{"no recipient", whatsapp.NewMessage().Text("hi", false), "phone number"},
+		{"two contents", whatsapp.NewMessage().To("1234567890").Text("hi", false).Image(whatsapp.MediaRef{ID: "m"}, ""), "already set to text"},
+		{"empty text", whatsapp.NewMessage().To("1234567890").Text("", false), "text body is required"},
+		{"invalid media", whatsapp.NewMessage().To("1234567890").Video(whatsapp.MediaRef{}, ""), "media ID or link is required"},
+		{"invalid template", whatsapp.NewMessage().To("1234567890").Template(whatsapp.TemplateMessage{Name: "t"}), "name and language are required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.sent", &body)
+	client := newTestClient(t, server)
+
+	msg, err := whatsapp.NewMessage().
+		To("1234567890").
+		ReplyTo("wamid.question").
+		Template(whatsapp.TemplateMessage{
+			Name:       "order_update",
+			Language:   "en",
+			Components: []whatsapp.TemplateMessageComponent{{Type: "body", Parameters: []whatsapp.TemplateParam{whatsapp.TextParam("42")}}},
+		}).
+		Build()
+	require.NoError(t, err)
+
+	msgID, err := client.Send(testutil.TestContext(t), testAccount(server.URL), msg)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.sent", msgID)
+
+	assert.Equal(t, "template", body["type"])
+	assert.Equal(t, "wamid.question", body["context"].(map[string]interface{})["message_id"])
+	template := body["template"].(map[string]interface{})
+	assert.Equal(t, "order_update", template["name"])
+	require.Len(t, template["components"], 1)
+}
+
+func TestClient_Send_Document(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.doc", &body)
+	client := newTestClient(t, server)
+
+	msg, err := whatsapp.NewMessage().To("1234567890").
+		Document(whatsapp.DocumentMessage{Link: "https://cdn.example.com/invoice.pdf", Filename: "invoice.pdf"}).
+		Build()
+	require.NoError(t, err)
+
+	_, err = client.Send(testutil.TestContext(t), testAccount(server.URL), msg)
+	require.NoError(t, err)
+	assert.NotContains(t, body, "context")
+	assert.Equal(t, map[string]interface{}{"link": "https://cdn.example.com/invoice.pdf", "filename": "invoice.pdf"}, body["document"])
+}
+
+func TestClient_WithReplyTo(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		send func(c *whatsapp.Client, account *whatsapp.Account, opts ...whatsapp.SendOption) (string, error)
+	}{
+		{"image", func(c *whatsapp.Client, account *whatsapp.Account, opts ...whatsapp.SendOption) (string, error) {
+			return c.SendImage(testutil.TestContext(t), account, "1234567890", whatsapp.MediaRef{ID: "media-1"}, "", opts...)
+		}},
+		{"location", func(c *whatsapp.Client, account *whatsapp.Account, opts ...whatsapp.SendOption) (string, error) {
+			return c.SendLocationMessage(testutil.TestContext(t), account, "1234567890", 12.9, 77.6, "", "", opts...)
+		}},
+		{"cta url", func(c *whatsapp.Client, account *whatsapp.Account, opts ...whatsapp.SendOption) (string, error) {
+			return c.SendCTAURLButton(testutil.TestContext(t), account, "1234567890", "Track your order", "Track", "https://example.com", opts...)
+		}},
+		{"template", func(c *whatsapp.Client, account *whatsapp.Account, opts ...whatsapp.SendOption) (string, error) {
+			return c.SendTemplateMessage(testutil.TestContext(t), account, "1234567890", "order_update", "en", nil, opts...)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := newMessageCaptureServer(t, "wamid.reply", &body)
+			defer server.Close()
+			client := newTestClient(t, server)
+			account := testAccount(server.URL)
+
+			_, err := tt.send(client, account, whatsapp.WithReplyTo("wamid.inbound"))
+			require.NoError(t, err)
+			assert.Equal(t, map[string]interface{}{"message_id": "wamid.inbound"}, body["context"])
+
+			body = nil
+			_, err = tt.send(client, account)
+			require.NoError(t, err)
+			assert.NotContains(t, body, "context")
+		})
+	}
+}
+
+func TestClient_WithCallbackData(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.tracked", &body)
+	defer server.Close()
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	_, err := client.SendTextMessage(testutil.TestContext(t), account, "1234567890", "Your order shipped")
+	require.NoError(t, err)
+	assert.NotContains(t, body, "biz_opaque_callback_data")
+
+	body = nil
+	_, err = client.SendTextMessage(testutil.TestContext(t), account, "1234567890", "Your order shipped",
+		whatsapp.WithCallbackData("order-42"), whatsapp.WithReplyTo("wamid.inbound"))
+	require.NoError(t, err)
+	assert.Equal(t, "order-42", body["biz_opaque_callback_data"])
+	assert.Equal(t, map[string]interface{}{"message_id": "wamid.inbound"}, body["context"])
+
+	body = nil
+	_, err = client.SendReaction(testutil.TestContext(t), account, "1234567890", "wamid.inbound", "👍",
+		whatsapp.WithCallbackData("order-42"))
+	require.NoError(t, err)
+	assert.Equal(t, "order-42", body["biz_opaque_callback_data"])
+
+	body = nil
+	_, err = client.SendImage(testutil.TestContext(t), account, "1234567890", whatsapp.MediaRef{ID: "media-1"}, "",
+		whatsapp.WithCallbackData("order-42"))
+	require.NoError(t, err)
+	assert.Equal(t, "order-42", body["biz_opaque_callback_data"])
+
+	body = nil
+	_, err = client.SendImage(testutil.TestContext(t), account, "1234567890", whatsapp.MediaRef{ID: "media-1"}, "",
+		whatsapp.WithCallbackData(strings.Repeat("x", 513)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds maximum of 512")
+	assert.Nil(t, body)
+
+	_, err = whatsapp.NewMessage().To("1234567890").CallbackData(strings.Repeat("x", 513)).Text("Hi", false).Build()
+	require.Error(t, err)
+}
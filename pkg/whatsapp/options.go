@@ -0,0 +1,103 @@
+package whatsapp
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client at construction time
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used for all requests, e.g. to route
+// traffic through a proxy or a custom transport
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.HTTPClient = httpClient
+		}
+	}
+}
+
+// WithTimeout sets the overall timeout for each HTTP request.
+// The HTTP client is copied so a client shared via WithHTTPClient is not modified.
+func WithTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		httpClient := *c.HTTPClient
+		httpClient.Timeout = timeout
+		c.HTTPClient = &httpClient
+	}
+}
+
+// WithBaseURL points the client at a different Graph API host, e.g. a local
+// mock server or a staging proxy. A trailing slash is ignored.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent on every request, e.g. to tell
+// environments apart in Meta's logs. It defaults to "whatomate/<Version>".
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithMaxResponseSize caps the bytes read from a Graph API response body,
+// e.g. to raise the limit for very large list pages. A negative size removes
+// the cap; media downloads are never limited.
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(c *Client) {
+		c.MaxResponseSize = size
+	}
+}
+
+// WithRetry sets the retry policy for transient Meta API failures
+func WithRetry(cfg RetryConfig) ClientOption {
+	return func(c *Client) {
+		c.Retry = cfg
+	}
+}
+
+// WithThrottle enables proactive throttling based on Meta's usage headers
+func WithThrottle(cfg ThrottleConfig) ClientOption {
+	return func(c *Client) {
+		c.Throttle = cfg
+	}
+}
+
+// WithCircuitBreaker makes requests fail fast with ErrCircuitOpen during a
+// Meta outage; see CircuitBreakerConfig
+func WithCircuitBreaker(cfg CircuitBreakerConfig) ClientOption {
+	return func(c *Client) {
+		c.Breaker = cfg
+	}
+}
+
+// WithRequestLogger traces each Graph API request, with credentials redacted
+func WithRequestLogger(logger RequestLogger) ClientOption {
+	return func(c *Client) {
+		c.RequestLog = logger
+	}
+}
+
+// WithObserver reports metrics for each Graph API request to the observer
+func WithObserver(observer Observer) ClientOption {
+	return func(c *Client) {
+		c.Observer = observer
+	}
+}
+
+// WithDryRun makes catalog mutations (product create, update, delete and batch
+// calls, catalog and product set changes) log their request bodies and return
+// synthetic "dry-run-N" IDs instead of calling Meta. Reads still execute, so
+// lookups such as UpsertProduct's work against the live catalog.
+func WithDryRun(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.DryRun = enabled
+	}
+}
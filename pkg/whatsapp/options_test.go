@@ -0,0 +1,195 @@
+package whatsapp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	require.NotNil(t, client.HTTPClient)
+	assert.Equal(t, whatsapp.DefaultTimeout, client.HTTPClient.Timeout)
+	assert.Zero(t, client.Retry.MaxAttempts)
+}
+
+func TestNew_WithHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: &testServerTransport{serverURL: server.URL}}
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithHTTPClient(httpClient))
+	assert.Same(t, httpClient, client.HTTPClient)
+
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+}
+
+func TestNew_WithTimeoutDoesNotModifySharedClient(t *testing.T) {
+	t.Parallel()
+
+	shared := &http.Client{Timeout: time.Minute}
+	client := whatsapp.New(testutil.NopLogger(),
+		whatsapp.WithHTTPClient(shared),
+		whatsapp.WithTimeout(5*time.Second),
+	)
+
+	assert.Equal(t, 5*time.Second, client.HTTPClient.Timeout)
+	assert.Equal(t, time.Minute, shared.Timeout)
+}
+
+func TestNew_WithRetryAndThrottle(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger(),
+		whatsapp.WithRetry(whatsapp.DefaultRetryConfig),
+		whatsapp.WithThrottle(whatsapp.ThrottleConfig{Threshold: 90}),
+		whatsapp.WithCircuitBreaker(whatsapp.CircuitBreakerConfig{FailureThreshold: 5}),
+	)
+
+	assert.Equal(t, whatsapp.DefaultRetryConfig, client.Retry)
+	assert.Equal(t, 90, client.Throttle.Threshold)
+	assert.Equal(t, 5, client.Breaker.FailureThreshold)
+}
+
+func TestNew_WithBaseURL(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"new-id"}`))
+	}))
+	defer server.Close()
+
+	// No transport rewriting: requests must reach the server via the base URL alone
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL+"/"))
+	account := testAccount(server.URL)
+	ctx := context.Background()
+
+	_, err := client.CreateCatalog(ctx, account, "My Catalog")
+	require.NoError(t, err)
+	_, err = client.CreateProduct(ctx, account, "catalog-123", &whatsapp.ProductInput{Name: "P", Price: 100, Currency: "USD", RetailerID: "SKU-1"})
+	require.NoError(t, err)
+	_, err = client.SubmitTemplate(ctx, account, &whatsapp.TemplateSubmission{MetaTemplateID: "tmpl-1", BodyContent: "Hi"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/v21.0/987654321/owned_product_catalogs",
+		"/v21.0/catalog-123/products",
+		"/tmpl-1",
+	}, paths)
+}
+
+func TestNew_WithUserAgent(t *testing.T) {
+	t.Parallel()
+
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"media-1","data":[]}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	account := testAccount(server.URL)
+
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL))
+	_, err := client.ListCatalogs(ctx, account)
+	require.NoError(t, err)
+
+	client = whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithUserAgent("whatomate-staging/1.2"))
+	_, err = client.ListCatalogs(ctx, account)
+	require.NoError(t, err)
+	_, err = client.UploadMediaStream(ctx, account, strings.NewReader("data"), "image/jpeg", "photo.jpg")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"whatomate/" + whatsapp.Version, "whatomate-staging/1.2", "whatomate-staging/1.2"}, userAgents)
+}
+
+func TestNew_WithMaxResponseSize(t *testing.T) {
+	t.Parallel()
+
+	page := `{"data":[{"id":"cat-1","name":"` + strings.Repeat("x", 100) + `"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if strings.HasSuffix(r.URL.Path, "/media") {
+			_, _ = w.Write([]byte(strings.Repeat("m", 500)))
+			return
+		}
+		_, _ = w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	account := testAccount(server.URL)
+
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithMaxResponseSize(64))
+	_, err := client.ListCatalogs(ctx, account)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, whatsapp.ErrResponseTooLarge)
+
+	// Media downloads are not limited
+	data, err := client.DownloadMedia(ctx, server.URL+"/media", "token")
+	require.NoError(t, err)
+	assert.Len(t, data, 500)
+
+	client = whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithMaxResponseSize(int64(len(page))))
+	catalogs, err := client.ListCatalogs(ctx, account)
+	require.NoError(t, err)
+	assert.Len(t, catalogs, 1)
+}
+
+func TestNew_WithDryRun(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"prod-1","name":"Live"}]}`))
+	}))
+	defer server.Close()
+
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL), whatsapp.WithDryRun(true))
+	account := testAccount(server.URL)
+	ctx := context.Background()
+	product := &whatsapp.ProductInput{Name: "P", Price: 100, Currency: "USD", RetailerID: "SKU-1"}
+
+	id, err := client.CreateProduct(ctx, account, "catalog-123", product)
+	require.NoError(t, err)
+	assert.Equal(t, "dry-run-1", id)
+
+	require.NoError(t, client.UpdateProduct(ctx, account, "prod-1", product))
+	require.NoError(t, client.DeleteProduct(ctx, account, "prod-1"))
+
+	results, err := client.BatchUpsertProducts(ctx, account, "catalog-123", []whatsapp.ProductInput{*product})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "dry-run-4", results[0].Handle)
+
+	// Reads still reach the API
+	products, err := client.ListCatalogProducts(ctx, account, "catalog-123")
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+
+	assert.Equal(t, []string{"GET /v21.0/catalog-123/products"}, requests)
+}
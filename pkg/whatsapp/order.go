@@ -0,0 +1,330 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// OrderStatus is the state of an order sent with an order details or order
+// status message
+type OrderStatus string
+
+// Order status values accepted by the payments API
+const (
+	OrderStatusPending          OrderStatus = "pending"
+	OrderStatusProcessing       OrderStatus = "processing"
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusCompleted        OrderStatus = "completed"
+	OrderStatusCanceled         OrderStatus = "canceled"
+)
+
+var validOrderStatuses = map[OrderStatus]bool{
+	OrderStatusPending:          true,
+	OrderStatusProcessing:       true,
+	OrderStatusPartiallyShipped: true,
+	OrderStatusShipped:          true,
+	OrderStatusCompleted:        true,
+	OrderStatusCanceled:         true,
+}
+
+// Valid reports whether s is one of the OrderStatus* values
+func (s OrderStatus) Valid() bool {
+	return validOrderStatuses[s]
+}
+
+// Order types of an order details message
+const (
+	OrderTypeDigitalGoods  = "digital-goods"
+	OrderTypePhysicalGoods = "physical-goods"
+)
+
+// maxOrderReferenceID is the maximum length of an order reference ID
+const maxOrderReferenceID = 35
+
+// validateOrderReferenceID checks an order's reference ID, which the customer's
+// payment and later status messages are matched on
+func validateOrderReferenceID(referenceID string) error {
+	if referenceID == "" {
+		return fmt.Errorf("order reference ID is required")
+	}
+	if n := utf8.RuneCountInString(referenceID); n > maxOrderReferenceID {
+		return fmt.Errorf("order reference ID is %d characters, exceeds maximum of %d", n, maxOrderReferenceID)
+	}
+	return nil
+}
+
+// SendOrderStatusMessage tells the customer that the order with the given
+// reference ID moved to a new status, e.g. after its payment was received.
+// bodyText is required.
+func (c *Client) SendOrderStatusMessage(ctx context.Context, account *Account, phoneNumber, referenceID string, status OrderStatus, bodyText string, opts ...SendOption) (string, error) {
+	if err := validateOrderReferenceID(referenceID); err != nil {
+		return "", err
+	}
+	if !status.Valid() {
+		return "", fmt.Errorf("invalid order status %q", status)
+	}
+	if bodyText == "" {
+		return "", fmt.Errorf("body text is required")
+	}
+
+	interactive := map[string]interface{}{
+		"type": "order_status",
+		"body": map[string]interface{}{
+			"text": bodyText,
+		},
+		"action": map[string]interface{}{
+			"name": "review_order",
+			"parameters": map[string]interface{}{
+				"reference_id": referenceID,
+				"order": map[string]interface{}{
+					"status": status,
+				},
+			},
+		},
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
+
+// OrderDetails describes the order and payment request of an order details
+// message. Amounts are in the currency's minor units (e.g. paise); the
+// subtotal and total are computed from the items, tax, shipping and discount.
+type OrderDetails struct {
+	ReferenceID string   // Required; unique ID of the order in your system, up to 35 characters
+	Type        string   // OrderTypeDigitalGoods or OrderTypePhysicalGoods
+	Currency    Currency // Meta currently supports INR only
+	CatalogID   string   // Optional; set when the items are products of a catalog
+	Items       []OrderDetailsItem
+
+	Tax                 int64
+	TaxDescription      string
+	Shipping            int64
+	ShippingDescription string
+	Discount            int64
+	DiscountDescription string
+
+	// ExpiresAt optionally stops the customer from paying after a point in time
+	ExpiresAt             time.Time
+	ExpirationDescription string
+
+	// PaymentSettings lists how the customer can pay, e.g. a payment gateway
+	// configured in WhatsApp Manager
+	PaymentSettings []PaymentSetting
+}
+
+// OrderDetailsItem is a line item of an order details message
+type OrderDetailsItem struct {
+	RetailerID string // Retailer ID (SKU) of the catalog product, or your own item ID
+	Name       string
+	Amount     int64 // Unit price in minor units
+	SaleAmount int64 // Optional discounted unit price in minor units; 0 means no sale
+	Quantity   int
+}
+
+// Payment setting types
+const (
+	PaymentSettingGateway = "payment_gateway"
+	PaymentSettingLink    = "payment_link"
+)
+
+// PaymentSetting is one way the customer can pay for an order. Type selects
+// which of Gateway or Link is sent.
+type PaymentSetting struct {
+	Type    string // PaymentSettingGateway or PaymentSettingLink
+	Gateway *PaymentGateway
+	Link    *PaymentLink
+}
+
+// PaymentGateway is a payment gateway configuration set up in WhatsApp Manager
+type PaymentGateway struct {
+	Type              string // Gateway name, e.g. "razorpay", "payu", "billdesk" or "zaakpay"
+	ConfigurationName string // Name of the configuration in WhatsApp Manager
+	// Options are gateway-specific fields sent as the object named by Type,
+	// e.g. {"receipt": "receipt-42", "notes": {"order": "42"}} for razorpay
+	Options map[string]interface{}
+}
+
+// PaymentLink is a link to a page where the customer pays
+type PaymentLink struct {
+	URI string
+}
+
+// payload builds the payment_setting object of an order details message
+func (s PaymentSetting) payload() (map[string]interface{}, error) {
+	switch s.Type {
+	case PaymentSettingGateway:
+		if s.Gateway == nil || s.Gateway.Type == "" || s.Gateway.ConfigurationName == "" {
+			return nil, fmt.Errorf("payment gateway type and configuration name are required")
+		}
+		gateway := map[string]interface{}{
+			"type":               s.Gateway.Type,
+			"configuration_name": s.Gateway.ConfigurationName,
+		}
+		if len(s.Gateway.Options) > 0 {
+			gateway[s.Gateway.Type] = s.Gateway.Options
+		}
+		return map[string]interface{}{
+			"type":            s.Type,
+			"payment_gateway": gateway,
+		}, nil
+	case PaymentSettingLink:
+		if s.Link == nil || s.Link.URI == "" {
+			return nil, fmt.Errorf("payment link URI is required")
+		}
+		return map[string]interface{}{
+			"type": s.Type,
+			"payment_link": map[string]interface{}{
+				"uri": s.Link.URI,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid payment setting type %q", s.Type)
+	}
+}
+
+// payload builds the parameters of an order details message
+func (o *OrderDetails) payload() (map[string]interface{}, error) {
+	if err := validateOrderReferenceID(o.ReferenceID); err != nil {
+		return nil, err
+	}
+	if o.Type != OrderTypeDigitalGoods && o.Type != OrderTypePhysicalGoods {
+		return nil, fmt.Errorf("invalid order type %q", o.Type)
+	}
+	if err := validateCurrency(o.Currency); err != nil {
+		return nil, err
+	}
+	if len(o.Items) == 0 {
+		return nil, fmt.Errorf("at least one order item is required")
+	}
+
+	// Amounts are sent as an integer value with the offset it is scaled by
+	offset := int64(1)
+	for i := 0; i < o.Currency.Exponent(); i++ {
+		offset *= 10
+	}
+	amount := func(value int64) map[string]interface{} {
+		return map[string]interface{}{"value": value, "offset": offset}
+	}
+
+	var subtotal int64
+	items := make([]map[string]interface{}, 0, len(o.Items))
+	for i, item := range o.Items {
+		if item.RetailerID == "" || item.Name == "" {
+			return nil, fmt.Errorf("order item %d: retailer ID and name are required", i+1)
+		}
+		if item.Quantity <= 0 {
+			return nil, fmt.Errorf("order item %d: quantity must be positive", i+1)
+		}
+		if item.Amount <= 0 {
+			return nil, fmt.Errorf("order item %d: amount must be positive", i+1)
+		}
+
+		entry := map[string]interface{}{
+			"retailer_id": item.RetailerID,
+			"name":        item.Name,
+			"amount":      amount(item.Amount),
+			"quantity":    item.Quantity,
+		}
+		unit := item.Amount
+		if item.SaleAmount > 0 {
+			entry["sale_amount"] = amount(item.SaleAmount)
+			unit = item.SaleAmount
+		}
+		subtotal += unit * int64(item.Quantity)
+		items = append(items, entry)
+	}
+
+	order := map[string]interface{}{
+		"status":   OrderStatusPending,
+		"items":    items,
+		"subtotal": amount(subtotal),
+	}
+	if o.CatalogID != "" {
+		order["catalog_id"] = o.CatalogID
+	}
+
+	// Tax is required by Meta even when zero; shipping and discount are optional
+	tax := amount(o.Tax)
+	if o.TaxDescription != "" {
+		tax["description"] = o.TaxDescription
+	}
+	order["tax"] = tax
+	if o.Shipping > 0 {
+		shipping := amount(o.Shipping)
+		if o.ShippingDescription != "" {
+			shipping["description"] = o.ShippingDescription
+		}
+		order["shipping"] = shipping
+	}
+	if o.Discount > 0 {
+		discount := amount(o.Discount)
+		if o.DiscountDescription != "" {
+			discount["description"] = o.DiscountDescription
+		}
+		order["discount"] = discount
+	}
+	if !o.ExpiresAt.IsZero() {
+		expiration := map[string]interface{}{
+			"timestamp": strconv.FormatInt(o.ExpiresAt.Unix(), 10),
+		}
+		if o.ExpirationDescription != "" {
+			expiration["description"] = o.ExpirationDescription
+		}
+		order["expiration"] = expiration
+	}
+
+	total := subtotal + o.Tax + o.Shipping - o.Discount
+	if total <= 0 {
+		return nil, fmt.Errorf("order total must be positive, got %s", FormatPrice(total, string(o.Currency)))
+	}
+
+	parameters := map[string]interface{}{
+		"reference_id": o.ReferenceID,
+		"type":         o.Type,
+		"currency":     o.Currency,
+		"total_amount": amount(total),
+		"order":        order,
+	}
+	if len(o.PaymentSettings) > 0 {
+		settings := make([]map[string]interface{}, 0, len(o.PaymentSettings))
+		for _, setting := range o.PaymentSettings {
+			payload, err := setting.payload()
+			if err != nil {
+				return nil, err
+			}
+			settings = append(settings, payload)
+		}
+		parameters["payment_settings"] = settings
+	}
+	return parameters, nil
+}
+
+// SendOrderDetailsMessage sends an order details message, a payment request
+// showing the order's line items and totals with a button to pay. bodyText
+// is required. Follow up with SendOrderStatusMessage as the order progresses.
+func (c *Client) SendOrderDetailsMessage(ctx context.Context, account *Account, phoneNumber, bodyText string, order OrderDetails, opts ...SendOption) (string, error) {
+	if bodyText == "" {
+		return "", fmt.Errorf("body text is required")
+	}
+	parameters, err := order.payload()
+	if err != nil {
+		return "", err
+	}
+
+	interactive := map[string]interface{}{
+		"type": "order_details",
+		"body": map[string]interface{}{
+			"text": bodyText,
+		},
+		"action": map[string]interface{}{
+			"name":       "review_and_pay",
+			"parameters": parameters,
+		},
+	}
+
+	return c.sendMessage(ctx, account, phoneNumber, "interactive", interactive, opts...)
+}
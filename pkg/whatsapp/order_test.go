@@ -0,0 +1,169 @@
+package whatsapp_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SendOrderStatusMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.status", &body)
+	client := newTestClient(t, server)
+
+	msgID, err := client.SendOrderStatusMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890",
+		"order-42", whatsapp.OrderStatusShipped, "Your order is on its way")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.status", msgID)
+
+	interactive, err := json.Marshal(body["interactive"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "order_status",
+		"body": {"text": "Your order is on its way"},
+		"action": {"name": "review_order", "parameters": {"reference_id": "order-42", "order": {"status": "shipped"}}}
+	}`, string(interactive))
+}
+
+func TestClient_SendOrderStatusMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.New(testutil.NopLogger())
+	tests := []struct {
+		name        string
+		referenceID string
+		status      whatsapp.OrderStatus
+		wantErr     string
+	}{
+		{"missing reference", "", whatsapp.OrderStatusShipped, "reference ID is required"},
+		{"long reference", strings.Repeat("x", 36), whatsapp.OrderStatusShipped, "exceeds maximum of 35"},
+		{"invalid status", "order-42", "delivered", "invalid order status"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.SendOrderStatusMessage(testutil.TestContext(t), testAccount(""), "1234567890", tt.referenceID, tt.status, "Update")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClient_SendOrderDetailsMessage(t *testing.T) {
+	t.Parallel()
+
+	var body map[string]interface{}
+	server := newMessageCaptureServer(t, "wamid.order", &body)
+	client := newTestClient(t, server)
+
+	order := whatsapp.OrderDetails{
+		ReferenceID: "order-42",
+		Type:        whatsapp.OrderTypePhysicalGoods,
+		Currency:    whatsapp.CurrencyINR,
+		CatalogID:   "catalog-1",
+		Items: []whatsapp.OrderDetailsItem{
+			{RetailerID: "SKU-1", Name: "T-Shirt", Amount: 50000, Quantity: 2},
+			{RetailerID: "SKU-2", Name: "Mug", Amount: 30000, SaleAmount: 25000, Quantity: 1},
+		},
+		Tax:                   9000,
+		TaxDescription:        "GST",
+		Shipping:              5000,
+		Discount:              10000,
+		ExpiresAt:             time.Unix(1700000000, 0),
+		ExpirationDescription: "Pay within a day",
+		PaymentSettings: []whatsapp.PaymentSetting{{
+			Type: whatsapp.PaymentSettingGateway,
+			Gateway: &whatsapp.PaymentGateway{
+				Type:              "razorpay",
+				ConfigurationName: "store-razorpay",
+				Options:           map[string]interface{}{"receipt": "receipt-42"},
+			},
+		}},
+	}
+
+	msgID, err := client.SendOrderDetailsMessage(testutil.TestContext(t), testAccount(server.URL), "1234567890", "Here is your order", order)
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.order", msgID)
+
+	interactive, err := json.Marshal(body["interactive"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "order_details",
+		"body": {"text": "Here is your order"},
+		"action": {
+			"name": "review_and_pay",
+			"parameters": {
+				"reference_id": "order-42",
+				"type": "physical-goods",
+				"currency": "INR",
+				"total_amount": {"value": 129000, "offset": 100},
+				"payment_settings": [{
+					"type": "payment_gateway",
+					"payment_gateway": {"type": "razorpay", "configuration_name": "store-razorpay", "razorpay": {"receipt": "receipt-42"}}
+				}],
+				"order": {
+					"status": "pending",
+					"catalog_id": "catalog-1",
+					"items": [
+						{"retailer_id": "SKU-1", "name": "T-Shirt", "amount": {"value": 50000, "offset": 100}, "quantity": 2},
+						{"retailer_id": "SKU-2", "name": "Mug", "amount": {"value": 30000, "offset": 100},
+							"sale_amount": {"value": 25000, "offset": 100}, "quantity": 1}
+					],
+					"subtotal": {"value": 125000, "offset": 100},
+					"tax": {"value": 9000, "offset": 100, "description": "GST"},
+					"shipping": {"value": 5000, "offset": 100},
+					"discount": {"value": 10000, "offset": 100},
+					"expiration": {"timestamp": "1700000000", "description": "Pay within a day"}
+				}
+			}
+		}
+	}`, string(interactive))
+}
+
+func TestClient_SendOrderDetailsMessage_Validation(t *testing.T) {
+	t.Parallel()
+
+	valid := func() whatsapp.OrderDetails {
+		return whatsapp.OrderDetails{
+			ReferenceID: "order-42",
+			Type:        whatsapp.OrderTypeDigitalGoods,
+			Currency:    whatsapp.CurrencyINR,
+			Items:       []whatsapp.OrderDetailsItem{{RetailerID: "SKU-1", Name: "E-book", Amount: 20000, Quantity: 1}},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(o *whatsapp.OrderDetails)
+		wantErr string
+	}{
+		{"invalid type", func(o *whatsapp.OrderDetails) { o.Type = "goods" }, "invalid order type"},
+		{"no items", func(o *whatsapp.OrderDetails) { o.Items = nil }, "at least one order item"},
+		{"zero quantity", func(o *whatsapp.OrderDetails) { o.Items[0].Quantity = 0 }, "quantity must be positive"},
+		{"discount exceeds total", func(o *whatsapp.OrderDetails) { o.Discount = 30000 }, "total must be positive"},
+		{"gateway without configuration", func(o *whatsapp.OrderDetails) {
+			o.PaymentSettings = []whatsapp.PaymentSetting{{Type: whatsapp.PaymentSettingGateway, Gateway: &whatsapp.PaymentGateway{Type: "payu"}}}
+		}, "configuration name are required"},
+		{"unknown payment setting", func(o *whatsapp.OrderDetails) {
+			o.PaymentSettings = []whatsapp.PaymentSetting{{Type: "upi"}}
+		}, "invalid payment setting type"},
+	}
+
+	client := whatsapp.New(testutil.NopLogger())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := valid()
+			tt.modify(&order)
+			_, err := client.SendOrderDetailsMessage(testutil.TestContext(t), testAccount(""), "1234567890", "Your order", order)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
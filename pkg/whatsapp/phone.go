@@ -0,0 +1,237 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// phoneNumberFields are the phone number fields requested when listing phone numbers
+const phoneNumberFields = "id,display_phone_number,verified_name,quality_rating,messaging_limit_tier,code_verification_status,name_status,new_name_status"
+
+// ListPhoneNumbers lists all phone numbers of the account's WhatsApp Business
+// Account, following pagination
+func (c *Client) ListPhoneNumbers(ctx context.Context, account *Account) ([]PhoneNumber, error) {
+	params := url.Values{}
+	params.Add("fields", phoneNumberFields)
+	apiURL := fmt.Sprintf("%s/%s/%s/phone_numbers", c.getBaseURL(), account.APIVersion, account.BusinessID)
+	numbers, err := listAll[PhoneNumber](ctx, c, account, apiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list phone numbers: %w", err)
+	}
+	return numbers, nil
+}
+
+// ErrInvalidPIN is returned when registering a phone number with a PIN that
+// does not match its two-step verification PIN
+var ErrInvalidPIN = errors.New("two-step verification PIN mismatch")
+
+// errCodePINMismatch is the Graph API error code for a wrong two-step verification PIN
+const errCodePINMismatch = 133005
+
+// validatePIN checks that a two-step verification PIN is exactly 6 digits
+func validatePIN(pin string) error {
+	if len(pin) != 6 || strings.Trim(pin, "0123456789") != "" {
+		return fmt.Errorf("PIN must be 6 digits")
+	}
+	return nil
+}
+
+// RegisterPhoneNumber registers the account's phone number for use with the
+// Cloud API. pin is the 6-digit two-step verification PIN; it is set as the
+// number's PIN if none is configured yet.
+func (c *Client) RegisterPhoneNumber(ctx context.Context, account *Account, pin string) error {
+	if err := validatePIN(pin); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"pin":               pin,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/register", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, body, account); err != nil {
+		var apiErr *GraphAPIError
+		if errors.As(err, &apiErr) && apiErr.Code == errCodePINMismatch {
+			return fmt.Errorf("failed to register phone number: %w: %w", ErrInvalidPIN, err)
+		}
+		return fmt.Errorf("failed to register phone number: %w", err)
+	}
+
+	c.Log.Info("Phone number registered", "phone_id", account.PhoneID)
+	return nil
+}
+
+// DeregisterPhoneNumber removes the account's phone number from the Cloud API
+func (c *Client) DeregisterPhoneNumber(ctx context.Context, account *Account) error {
+	apiURL := fmt.Sprintf("%s/%s/%s/deregister", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, nil, account); err != nil {
+		return fmt.Errorf("failed to deregister phone number: %w", err)
+	}
+
+	c.Log.Info("Phone number deregistered", "phone_id", account.PhoneID)
+	return nil
+}
+
+// doPhoneNumberAction posts to a phone number edge that responds with {"success": bool}
+func (c *Client) doPhoneNumberAction(ctx context.Context, apiURL string, body interface{}, account *Account) error {
+	respBody, err := c.doRequest(ctx, http.MethodPost, apiURL, body, account)
+	if err != nil {
+		return err
+	}
+
+	var resp struct {
+		Success bool `json:"success"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("request was not successful")
+	}
+	return nil
+}
+
+// SetTwoStepPIN sets or changes the two-step verification PIN of the account's
+// phone number. Meta does not allow removing the PIN through the API; it can
+// only be disabled in WhatsApp Manager.
+func (c *Client) SetTwoStepPIN(ctx context.Context, account *Account, pin string) error {
+	if err := validatePIN(pin); err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"pin": pin,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, body, account); err != nil {
+		return fmt.Errorf("failed to set two-step verification PIN: %w", err)
+	}
+
+	c.Log.Info("Two-step verification PIN set", "phone_id", account.PhoneID)
+	return nil
+}
+
+// Verification code delivery methods for RequestPhoneVerificationCode
+const (
+	CodeMethodSMS   = "SMS"
+	CodeMethodVoice = "VOICE"
+)
+
+// RequestPhoneVerificationCode sends a verification code to the account's
+// phone number by SMS or voice call. language is the locale of the message,
+// e.g. "en_US"; it defaults to en_US when empty.
+func (c *Client) RequestPhoneVerificationCode(ctx context.Context, account *Account, codeMethod, language string) error {
+	if codeMethod != CodeMethodSMS && codeMethod != CodeMethodVoice {
+		return fmt.Errorf("invalid code method %q: must be %s or %s", codeMethod, CodeMethodSMS, CodeMethodVoice)
+	}
+	if language == "" {
+		language = "en_US"
+	}
+
+	body := map[string]interface{}{
+		"code_method": codeMethod,
+		"language":    language,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/request_code", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, body, account); err != nil {
+		return fmt.Errorf("failed to request verification code: %w", err)
+	}
+
+	c.Log.Info("Phone verification code requested", "phone_id", account.PhoneID, "method", codeMethod)
+	return nil
+}
+
+// VerifyPhoneNumber verifies the account's phone number with the code
+// received after RequestPhoneVerificationCode
+func (c *Client) VerifyPhoneNumber(ctx context.Context, account *Account, code string) error {
+	if code == "" {
+		return fmt.Errorf("verification code is required")
+	}
+
+	body := map[string]interface{}{
+		"code": code,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/verify_code", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, body, account); err != nil {
+		return fmt.Errorf("failed to verify phone number: %w", err)
+	}
+
+	c.Log.Info("Phone number verified", "phone_id", account.PhoneID)
+	return nil
+}
+
+// RequestDisplayNameChange submits a new display name for the account's phone
+// number. The change is reviewed by Meta; poll NewNameStatus on the number
+// returned by ListPhoneNumbers for the outcome.
+func (c *Client) RequestDisplayNameChange(ctx context.Context, account *Account, newName string) error {
+	if strings.TrimSpace(newName) == "" {
+		return fmt.Errorf("display name is required")
+	}
+
+	body := map[string]interface{}{
+		"new_display_name": newName,
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s", c.getBaseURL(), account.APIVersion, account.PhoneID)
+	if err := c.doPhoneNumberAction(ctx, apiURL, body, account); err != nil {
+		return fmt.Errorf("failed to request display name change: %w", err)
+	}
+
+	c.Log.Info("Display name change requested", "phone_id", account.PhoneID, "name", newName)
+	return nil
+}
+
+// buildCommerceSettingsURL builds the commerce settings URL of the account's phone number
+func (c *Client) buildCommerceSettingsURL(account *Account) string {
+	return fmt.Sprintf("%s/%s/%s/whatsapp_commerce_settings", c.getBaseURL(), account.APIVersion, account.PhoneID)
+}
+
+// GetCommerceSettings returns the cart and catalog visibility settings of the
+// account's phone number
+func (c *Client) GetCommerceSettings(ctx context.Context, account *Account) (*CommerceSettings, error) {
+	respBody, err := c.doRequest(ctx, http.MethodGet, c.buildCommerceSettingsURL(account), nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commerce settings: %w", err)
+	}
+
+	var resp struct {
+		Data []CommerceSettings `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// Numbers that never changed their settings report none, which means both are off
+	if len(resp.Data) == 0 {
+		return &CommerceSettings{}, nil
+	}
+	return &resp.Data[0], nil
+}
+
+// UpdateCommerceSettings enables or disables the cart and the catalog on the
+// account's phone number, and returns the settings as Meta reports them after
+// the change. The catalog must be visible for product messages to render.
+func (c *Client) UpdateCommerceSettings(ctx context.Context, account *Account, cartEnabled, catalogVisible bool) (*CommerceSettings, error) {
+	// Meta reads the settings from query parameters rather than the body
+	params := url.Values{}
+	params.Add("is_cart_enabled", strconv.FormatBool(cartEnabled))
+	params.Add("is_catalog_visible", strconv.FormatBool(catalogVisible))
+	apiURL := c.buildCommerceSettingsURL(account) + "?" + params.Encode()
+
+	if err := c.doPhoneNumberAction(ctx, apiURL, nil, account); err != nil {
+		return nil, fmt.Errorf("failed to update commerce settings: %w", err)
+	}
+
+	c.Log.Info("Commerce settings updated", "phone_id", account.PhoneID, "cart_enabled", cartEnabled, "catalog_visible", catalogVisible)
+	return c.GetCommerceSettings(ctx, account)
+}
@@ -0,0 +1,280 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListPhoneNumbers(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/987654321/phone_numbers", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("fields"), "messaging_limit_tier")
+
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{
+					"id":                   "111",
+					"display_phone_number": "+1 555-0100",
+					"verified_name":        "Acme",
+					"quality_rating":       "GREEN",
+					"messaging_limit_tier": "TIER_1K",
+					"name_status":          "APPROVED",
+					"new_name_status":      "PENDING_REVIEW",
+				}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "next"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "222", "quality_rating": "YELLOW"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	numbers, err := client.ListPhoneNumbers(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	require.Len(t, numbers, 2)
+	assert.Equal(t, "111", numbers[0].ID)
+	assert.Equal(t, "Acme", numbers[0].VerifiedName)
+	assert.Equal(t, "TIER_1K", numbers[0].MessagingLimitTier)
+	assert.Equal(t, "APPROVED", numbers[0].NameStatus)
+	assert.Equal(t, "PENDING_REVIEW", numbers[0].NewNameStatus)
+	assert.Equal(t, "YELLOW", numbers[1].QualityRating)
+}
+
+func TestClient_RegisterPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789/register", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "whatsapp", body["messaging_product"])
+		assert.Equal(t, "123456", body["pin"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.RegisterPhoneNumber(context.Background(), testAccount(server.URL), "123456"))
+}
+
+func TestClient_RegisterPhoneNumber_WrongPIN(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Two step verification PIN Mismatch","type":"OAuthException","code":133005}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.RegisterPhoneNumber(context.Background(), testAccount(server.URL), "654321")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, whatsapp.ErrInvalidPIN)
+}
+
+func TestClient_RegisterPhoneNumber_InvalidPIN(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid PIN should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	for _, pin := range []string{"", "12345", "1234567", "12a456"} {
+		assert.Error(t, client.RegisterPhoneNumber(context.Background(), testAccount(server.URL), pin), pin)
+	}
+}
+
+func TestClient_DeregisterPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789/deregister", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.DeregisterPhoneNumber(context.Background(), testAccount(server.URL)))
+}
+
+func TestClient_SetTwoStepPIN(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "654321", body["pin"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.SetTwoStepPIN(context.Background(), testAccount(server.URL), "654321"))
+}
+
+func TestClient_SetTwoStepPIN_InvalidPIN(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid PIN should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	for _, pin := range []string{"", "12345", "12a456"} {
+		assert.Error(t, client.SetTwoStepPIN(context.Background(), testAccount(server.URL), pin), pin)
+	}
+}
+
+func TestClient_RequestPhoneVerificationCode(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789/request_code", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "VOICE", body["code_method"])
+		assert.Equal(t, "en_US", body["language"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.RequestPhoneVerificationCode(context.Background(), testAccount(server.URL), whatsapp.CodeMethodVoice, ""))
+}
+
+func TestClient_RequestPhoneVerificationCode_InvalidMethod(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid code method should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.RequestPhoneVerificationCode(context.Background(), testAccount(server.URL), "EMAIL", "en_US")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid code method")
+}
+
+func TestClient_VerifyPhoneNumber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/123456789/verify_code", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "482913", body["code"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.VerifyPhoneNumber(context.Background(), testAccount(server.URL), "482913"))
+}
+
+func TestClient_RequestDisplayNameChange(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/123456789", r.URL.Path)
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Acme Support", body["new_display_name"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	require.NoError(t, client.RequestDisplayNameChange(context.Background(), testAccount(server.URL), "Acme Support"))
+}
+
+func TestClient_RequestDisplayNameChange_EmptyName(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("empty display name should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.RequestDisplayNameChange(context.Background(), testAccount(server.URL), " ")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "display name is required")
+}
+
+func TestClient_CommerceSettings(t *testing.T) {
+	t.Parallel()
+
+	var cartEnabled, catalogVisible bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/123456789/whatsapp_commerce_settings", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodPost {
+			cartEnabled = r.URL.Query().Get("is_cart_enabled") == "true"
+			catalogVisible = r.URL.Query().Get("is_catalog_visible") == "true"
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"success": true})
+			return
+		}
+		data := []map[string]interface{}{}
+		if cartEnabled || catalogVisible {
+			data = append(data, map[string]interface{}{"id": "settings-1", "is_cart_enabled": cartEnabled, "is_catalog_visible": catalogVisible})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	settings, err := client.GetCommerceSettings(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, &whatsapp.CommerceSettings{}, settings)
+
+	settings, err = client.UpdateCommerceSettings(context.Background(), account, false, true)
+	require.NoError(t, err)
+	assert.Equal(t, &whatsapp.CommerceSettings{ID: "settings-1", IsCatalogVisible: true}, settings)
+}
@@ -0,0 +1,310 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CSVColumnMapping names the CSV header column read into each ProductInput
+// field. Header names are matched case-insensitively; an empty name means the
+// field is not read from the file.
+type CSVColumnMapping struct {
+	RetailerID   string // Required
+	Name         string
+	Description  string
+	Price        string // Decimal price such as "12.99", optionally followed by the currency, e.g. "12.99 USD"
+	Currency     string
+	URL          string
+	ImageURL     string
+	Availability string
+	Condition    string
+	SalePrice    string // Decimal price in the product's currency
+	// DefaultCurrency is used for rows without a currency column or value
+	DefaultCurrency Currency
+}
+
+// DefaultCSVColumnMapping reads the column names used by Meta's catalog data feeds
+var DefaultCSVColumnMapping = CSVColumnMapping{
+	RetailerID:   "id",
+	Name:         "title",
+	Description:  "description",
+	Price:        "price",
+	Currency:     "currency",
+	URL:          "link",
+	ImageURL:     "image_link",
+	Availability: "availability",
+	Condition:    "condition",
+	SalePrice:    "sale_price",
+}
+
+// ImportProductsCSV reads products from a CSV file whose first row is the
+// header and upserts them into a catalog with BatchUpsertProducts. Rows that
+// cannot be parsed or fail validation are reported in the result without
+// stopping the import. Items holds one result per data row, in file order;
+// since the batch API does not tell creates from updates, accepted rows are
+// counted as Updated and carry the batch handle, and Meta may still reject
+// them while processing the batch.
+func (c *Client) ImportProductsCSV(ctx context.Context, account *Account, catalogID string, r io.Reader, mapping CSVColumnMapping) (SyncResult, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return SyncResult{}, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns, err := mapping.columns(header)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	var items []SyncItemResult
+	var products []ProductInput
+	var productItems []int       // Index in items of each product
+	seen := make(map[string]int) // Line of each imported retailer ID
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var line int
+		var csvErr *csv.ParseError
+		switch {
+		case err == nil || errors.Is(err, csv.ErrFieldCount):
+			line, _ = reader.FieldPos(0)
+		case errors.As(err, &csvErr):
+			// A malformed row, e.g. with a stray quote; record may have no fields
+			line = csvErr.Line
+		default:
+			return SyncResult{}, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if err == nil {
+			product, parseErr := columns.product(record, mapping.DefaultCurrency)
+			if parseErr == nil {
+				// Validate up front, as one invalid product fails the whole batch call
+				_, parseErr = buildProductBody(&product, false)
+			}
+			if parseErr == nil {
+				if first, ok := seen[product.RetailerID]; ok {
+					parseErr = fmt.Errorf("duplicate retailer ID %q, first seen on line %d", product.RetailerID, first)
+				}
+			}
+			err = parseErr
+			if err == nil {
+				seen[product.RetailerID] = line
+				products = append(products, product)
+				productItems = append(productItems, len(items))
+			}
+		}
+
+		item := SyncItemResult{RetailerID: columns.value(record, columns.retailerID)}
+		if err != nil {
+			item.Err = fmt.Errorf("line %d: %w", line, err)
+		}
+		items = append(items, item)
+	}
+
+	results, err := c.BatchUpsertProducts(ctx, account, catalogID, products)
+	for i, productItem := range productItems {
+		switch {
+		case i < len(results):
+			items[productItem].Handle = results[i].Handle
+			items[productItem].Err = results[i].Err
+		case err != nil:
+			items[productItem].Err = err
+		}
+	}
+
+	result := SyncResult{Items: items}
+	for _, item := range items {
+		if item.Err != nil {
+			result.Failed++
+		} else {
+			result.Updated++
+		}
+	}
+
+	c.Log.Info("Product CSV import finished", "catalog_id", catalogID, "rows", len(items), "failed", result.Failed)
+	return result, err
+}
+
+// productCSVValues maps each column ExportProductsCSV can write to its value
+var productCSVValues = map[string]func(p *ProductInfo) string{
+	"id":           func(p *ProductInfo) string { return p.RetailerID },
+	"title":        func(p *ProductInfo) string { return p.Name },
+	"description":  func(p *ProductInfo) string { return p.Description },
+	"price":        func(p *ProductInfo) string { return plainPrice(p.Price) },
+	"currency":     func(p *ProductInfo) string { return p.Currency },
+	"link":         func(p *ProductInfo) string { return p.URL },
+	"image_link":   func(p *ProductInfo) string { return p.ImageURL },
+	"availability": func(p *ProductInfo) string { return p.Availability },
+	"condition":    func(p *ProductInfo) string { return p.Condition },
+	"product_id":   func(p *ProductInfo) string { return p.ID },
+}
+
+// ProductCSVColumns lists the columns ExportProductsCSV writes by default, in
+// order. They follow DefaultCSVColumnMapping, so "id" is the retailer ID and
+// the Meta product ID is written as "product_id". Prices are written as plain
+// decimals; sale prices are not exported, so clear SalePrice in the mapping
+// when importing an export.
+var ProductCSVColumns = []string{"id", "title", "description", "price", "currency", "link", "image_link", "availability", "condition", "product_id"}
+
+// ExportProductsCSV writes every product in a catalog to w as CSV, with a
+// header row followed by one row per product. columns selects and orders the
+// columns from ProductCSVColumns; none writes them all. Rows are written page
+// by page as they are fetched, so the catalog is never held in memory.
+func (c *Client) ExportProductsCSV(ctx context.Context, account *Account, catalogID string, w io.Writer, columns ...string) error {
+	if len(columns) == 0 {
+		columns = ProductCSVColumns
+	}
+	values := make([]func(p *ProductInfo) string, len(columns))
+	for i, column := range columns {
+		value, ok := productCSVValues[column]
+		if !ok {
+			return fmt.Errorf("unknown product CSV column %q", column)
+		}
+		values[i] = value
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	record := make([]string, len(columns))
+	rows := 0
+	var writeErr error
+	err := walkPages(func(cursor string) (*Page[ProductInfo], error) {
+		return c.ListCatalogProductsPaginated(ctx, account, catalogID, cursor, productListPageLimit)
+	}, func(page *Page[ProductInfo]) bool {
+		for i := range page.Data {
+			for j, value := range values {
+				record[j] = value(&page.Data[i])
+			}
+			if writeErr = writer.Write(record); writeErr != nil {
+				return false
+			}
+		}
+		writer.Flush()
+		if writeErr = writer.Error(); writeErr != nil {
+			return false
+		}
+		rows += len(page.Data)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if writeErr != nil {
+		return fmt.Errorf("failed to write CSV: %w", writeErr)
+	}
+
+	c.Log.Info("Product CSV export finished", "catalog_id", catalogID, "rows", rows)
+	return nil
+}
+
+// csvColumns holds the record index of each mapped column; -1 if absent
+type csvColumns struct {
+	retailerID, name, description, price, currency, url, imageURL, availability, condition, salePrice int
+}
+
+// columns resolves the mapped column names against a CSV header
+func (m CSVColumnMapping) columns(header []string) (csvColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff") // Byte order mark written by spreadsheet tools
+		}
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	var missing []string
+	lookup := func(name string) int {
+		if name == "" {
+			return -1
+		}
+		i, ok := index[strings.ToLower(name)]
+		if !ok {
+			missing = append(missing, name)
+			return -1
+		}
+		return i
+	}
+
+	if m.RetailerID == "" {
+		return csvColumns{}, fmt.Errorf("retailer ID column is required")
+	}
+	cols := csvColumns{
+		retailerID:   lookup(m.RetailerID),
+		name:         lookup(m.Name),
+		description:  lookup(m.Description),
+		price:        lookup(m.Price),
+		currency:     lookup(m.Currency),
+		url:          lookup(m.URL),
+		imageURL:     lookup(m.ImageURL),
+		availability: lookup(m.Availability),
+		condition:    lookup(m.Condition),
+		salePrice:    lookup(m.SalePrice),
+	}
+	if len(missing) > 0 {
+		return csvColumns{}, fmt.Errorf("CSV header is missing columns: %s", strings.Join(missing, ", "))
+	}
+	return cols, nil
+}
+
+// value returns the trimmed field at index i, or "" if the column is not mapped
+func (cols csvColumns) value(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// product builds a ProductInput from a CSV record
+func (cols csvColumns) product(record []string, defaultCurrency Currency) (ProductInput, error) {
+	product := ProductInput{
+		RetailerID:   cols.value(record, cols.retailerID),
+		Name:         cols.value(record, cols.name),
+		Description:  cols.value(record, cols.description),
+		Currency:     Currency(cols.value(record, cols.currency)),
+		URL:          cols.value(record, cols.url),
+		ImageURL:     cols.value(record, cols.imageURL),
+		Availability: Availability(cols.value(record, cols.availability)),
+		Condition:    cols.value(record, cols.condition),
+	}
+	if product.RetailerID == "" {
+		return product, fmt.Errorf("retailer ID is required")
+	}
+
+	// Data feeds commonly write the currency after the amount, e.g. "12.99 USD"
+	price := cols.value(record, cols.price)
+	if amount, currency, ok := strings.Cut(price, " "); ok {
+		price = amount
+		if product.Currency == "" {
+			product.Currency = Currency(strings.TrimSpace(currency))
+		}
+	}
+	if product.Currency == "" {
+		product.Currency = defaultCurrency
+	}
+
+	if price != "" {
+		minorUnits, err := ParsePrice(price, string(product.Currency))
+		if err != nil {
+			return product, err
+		}
+		product.Price = minorUnits
+	}
+	if salePrice := cols.value(record, cols.salePrice); salePrice != "" {
+		amount, _, _ := strings.Cut(salePrice, " ")
+		minorUnits, err := ParsePrice(amount, string(product.Currency))
+		if err != nil {
+			return product, fmt.Errorf("sale price: %w", err)
+		}
+		product.SalePrice = minorUnits
+	}
+	return product, nil
+}
@@ -0,0 +1,216 @@
+package whatsapp_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ImportProductsCSV(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/catalog-1/batch", r.URL.Path)
+
+		var body struct {
+			Requests []struct {
+				RetailerID string                 `json:"retailer_id"`
+				Data       map[string]interface{} `json:"data"`
+			} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Requests, 2)
+		assert.Equal(t, "SKU-1", body.Requests[0].RetailerID)
+		assert.Equal(t, "T-Shirt", body.Requests[0].Data["name"])
+		assert.Equal(t, "12.99", body.Requests[0].Data["price"])
+		assert.Equal(t, "USD", body.Requests[0].Data["currency"])
+		assert.Equal(t, "SKU-3", body.Requests[1].RetailerID)
+		assert.Equal(t, "1.500", body.Requests[1].Data["price"])
+		assert.Equal(t, "BHD", body.Requests[1].Data["currency"])
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"handles": []string{"handle-1"}})
+	}))
+	defer server.Close()
+
+	csvData := "\ufeffSKU,Title,Price,Currency,Link,Image\n" +
+		"SKU-1,T-Shirt,12.99 USD,,https://example.com/1,https://example.com/1.jpg\n" +
+		"SKU-2,Mug,twelve,USD,https://example.com/2,https://example.com/2.jpg\n" +
+		"SKU-3,Lamp,1.5,BHD,https://example.com/3,https://example.com/3.jpg\n" +
+		"SKU-1,T-Shirt XL,14.99 USD,,https://example.com/4,https://example.com/4.jpg\n"
+	mapping := whatsapp.CSVColumnMapping{
+		RetailerID: "sku",
+		Name:       "title",
+		Price:      "price",
+		Currency:   "currency",
+		URL:        "link",
+		ImageURL:   "image",
+	}
+
+	client := newTestClient(t, server)
+	result, err := client.ImportProductsCSV(context.Background(), testAccount(server.URL), "catalog-1", strings.NewReader(csvData), mapping)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Updated)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, result.Items, 4)
+	assert.Equal(t, "handle-1", result.Items[0].Handle)
+	assert.NoError(t, result.Items[0].Err)
+	assert.Equal(t, "SKU-2", result.Items[1].RetailerID)
+	require.Error(t, result.Items[1].Err)
+	assert.Contains(t, result.Items[1].Err.Error(), "line 3")
+	assert.NoError(t, result.Items[2].Err)
+	require.Error(t, result.Items[3].Err)
+	assert.Contains(t, result.Items[3].Err.Error(), "first seen on line 2")
+}
+
+func TestClient_ImportProductsCSV_MalformedRow(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []struct {
+				RetailerID string `json:"retailer_id"`
+			} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		require.Len(t, body.Requests, 1)
+		assert.Equal(t, "SKU-2", body.Requests[0].RetailerID)
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"handles": []string{"handle-1"}})
+	}))
+	defer server.Close()
+
+	csvData := "id,title,description,price,currency,link,image_link,availability,condition,sale_price\n" +
+		"\"abc\"x,c\n" +
+		"SKU-2,Mug,A mug,8.00,USD,https://example.com/2,https://example.com/2.jpg,in stock,new,\n"
+
+	client := newTestClient(t, server)
+	result, err := client.ImportProductsCSV(context.Background(), testAccount(server.URL), "catalog-1",
+		strings.NewReader(csvData), whatsapp.DefaultCSVColumnMapping)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	require.Error(t, result.Items[0].Err)
+	assert.Contains(t, result.Items[0].Err.Error(), "line 2")
+	assert.NoError(t, result.Items[1].Err)
+	assert.Equal(t, 1, result.Failed)
+}
+
+func TestClient_ImportProductsCSV_MissingColumn(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("CSV with missing columns should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ImportProductsCSV(context.Background(), testAccount(server.URL), "catalog-1",
+		strings.NewReader("id,title\nSKU-1,T-Shirt\n"), whatsapp.DefaultCSVColumnMapping)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing columns: description, price")
+}
+
+func TestClient_ExportProductsCSV(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"id": "prod-1", "retailer_id": "SKU-1", "name": "T-Shirt", "price": "12.99"},
+					{"id": "prod-2", "retailer_id": "SKU-2", "name": "Mug, large", "price": "8.00"},
+				},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "cursor-2"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "prod-3", "retailer_id": "SKU-3", "name": "Lamp", "price": "1.500"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	var buf bytes.Buffer
+	err := client.ExportProductsCSV(context.Background(), testAccount(server.URL), "catalog-1", &buf, "id", "title", "price")
+	require.NoError(t, err)
+	assert.Equal(t, "id,title,price\nSKU-1,T-Shirt,12.99\nSKU-2,\"Mug, large\",8.00\nSKU-3,Lamp,1.500\n", buf.String())
+}
+
+func TestClient_ExportProductsCSV_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var imported []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{
+					"id": "prod-1", "retailer_id": "SKU-1", "name": "T-Shirt", "description": "Cotton tee",
+					"price": "$1,019.99", "currency": "USD", "url": "https://example.com/1", "image_url": "https://example.com/1.jpg",
+					"availability": "in stock", "condition": "new",
+				}},
+			})
+			return
+		}
+
+		var body struct {
+			Requests []struct {
+				Data map[string]interface{} `json:"data"`
+			} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		for _, req := range body.Requests {
+			imported = append(imported, req.Data)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"handles": []string{"handle-1"}})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	account := testAccount(server.URL)
+
+	var buf bytes.Buffer
+	require.NoError(t, client.ExportProductsCSV(context.Background(), account, "catalog-1", &buf))
+	assert.Contains(t, buf.String(), ",1019.99,USD,")
+
+	mapping := whatsapp.DefaultCSVColumnMapping
+	mapping.SalePrice = ""
+	result, err := client.ImportProductsCSV(context.Background(), account, "catalog-2", &buf, mapping)
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.NoError(t, result.Items[0].Err)
+
+	require.Len(t, imported, 1)
+	assert.Equal(t, "T-Shirt", imported[0]["name"])
+	assert.Equal(t, "1019.99", imported[0]["price"])
+	assert.Equal(t, "USD", imported[0]["currency"])
+	assert.Equal(t, "in stock", imported[0]["availability"])
+}
+
+func TestClient_ExportProductsCSV_UnknownColumn(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unknown column should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.ExportProductsCSV(context.Background(), testAccount(server.URL), "catalog-1", &bytes.Buffer{}, "id", "colour")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown product CSV column "colour"`)
+}
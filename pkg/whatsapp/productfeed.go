@@ -0,0 +1,105 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Feed fetch intervals for CreateProductFeed
+const (
+	FeedScheduleHourly = "HOURLY"
+	FeedScheduleDaily  = "DAILY"
+	FeedScheduleWeekly = "WEEKLY"
+)
+
+// FeedUpload is a single fetch of a product feed by Meta
+type FeedUpload struct {
+	ID                string `json:"id"`
+	StartTime         string `json:"start_time"`
+	EndTime           string `json:"end_time"` // Empty while the upload is still processing
+	ErrorCount        int    `json:"error_count"`
+	WarningCount      int    `json:"warning_count"`
+	NumDetectedItems  int    `json:"num_detected_items"`
+	NumPersistedItems int    `json:"num_persisted_items"`
+	URL               string `json:"url"`
+}
+
+// feedUploadFields are the upload fields requested by GetFeedUploadStatus
+const feedUploadFields = "id,start_time,end_time,error_count,warning_count,num_detected_items,num_persisted_items,url"
+
+// ErrFeedUploadNotFound is returned when a product feed has not been fetched yet.
+// It also matches ErrNotFound.
+var ErrFeedUploadNotFound = fmt.Errorf("feed upload %w", ErrNotFound)
+
+// CreateProductFeed creates a scheduled data feed in a catalog and returns the
+// feed ID. Meta fetches the file at feedURL on the given schedule, one of the
+// FeedSchedule* values, which suits large catalogs better than item calls.
+func (c *Client) CreateProductFeed(ctx context.Context, account *Account, catalogID, name, feedURL, schedule string) (string, error) {
+	if name == "" || feedURL == "" {
+		return "", fmt.Errorf("feed name and URL are required")
+	}
+	switch schedule {
+	case FeedScheduleHourly, FeedScheduleDaily, FeedScheduleWeekly:
+	default:
+		return "", fmt.Errorf("invalid feed schedule %q: must be %s, %s or %s", schedule, FeedScheduleHourly, FeedScheduleDaily, FeedScheduleWeekly)
+	}
+
+	scheduleJSON, err := json.Marshal(map[string]string{
+		"interval": schedule,
+		"url":      feedURL,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal feed schedule: %w", err)
+	}
+
+	body := map[string]string{
+		"name":     name,
+		"schedule": string(scheduleJSON),
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s/product_feeds", c.getBaseURL(), account.APIVersion, catalogID)
+	respBody, _, err := c.doCatalogWrite(ctx, http.MethodPost, apiURL, body, account)
+	if err != nil {
+		return "", fmt.Errorf("failed to create product feed: %w", err)
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	c.Log.Info("Product feed created", "catalog_id", catalogID, "feed_id", resp.ID, "schedule", schedule)
+	return resp.ID, nil
+}
+
+// GetFeedUploadStatus returns the most recent upload of a product feed,
+// including the number of errors and warnings Meta found in the file
+func (c *Client) GetFeedUploadStatus(ctx context.Context, account *Account, feedID string) (*FeedUpload, error) {
+	params := url.Values{}
+	params.Add("fields", feedUploadFields)
+	params.Add("limit", "1")
+	apiURL := fmt.Sprintf("%s/%s/%s/uploads?%s", c.getBaseURL(), account.APIVersion, feedID, params.Encode())
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feed uploads: %w", err)
+	}
+
+	var resp struct {
+		Data []FeedUpload `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, ErrFeedUploadNotFound
+	}
+
+	// Meta lists uploads newest first
+	return &resp.Data[0], nil
+}
@@ -0,0 +1,96 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_CreateProductFeed(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v21.0/catalog-1/product_feeds", r.URL.Path)
+
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Nightly feed", body["name"])
+		assert.JSONEq(t, `{"interval":"DAILY","url":"https://example.com/feed.csv"}`, body["schedule"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"feed-1"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	id, err := client.CreateProductFeed(context.Background(), testAccount(server.URL), "catalog-1", "Nightly feed", "https://example.com/feed.csv", whatsapp.FeedScheduleDaily)
+	require.NoError(t, err)
+	assert.Equal(t, "feed-1", id)
+}
+
+func TestClient_CreateProductFeed_InvalidSchedule(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid schedule should not reach the API")
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.CreateProductFeed(context.Background(), testAccount(server.URL), "catalog-1", "Feed", "https://example.com/feed.csv", "MONTHLY")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid feed schedule")
+}
+
+func TestClient_GetFeedUploadStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/feed-1/uploads", r.URL.Path)
+		assert.Contains(t, r.URL.Query().Get("fields"), "error_count")
+
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{
+				"id":                  "upload-1",
+				"start_time":          "2026-10-01T02:00:00+0000",
+				"end_time":            "2026-10-01T02:05:00+0000",
+				"error_count":         3,
+				"warning_count":       12,
+				"num_detected_items":  50000,
+				"num_persisted_items": 49997,
+			}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	upload, err := client.GetFeedUploadStatus(context.Background(), testAccount(server.URL), "feed-1")
+	require.NoError(t, err)
+	assert.Equal(t, "upload-1", upload.ID)
+	assert.Equal(t, 3, upload.ErrorCount)
+	assert.Equal(t, 12, upload.WarningCount)
+	assert.Equal(t, 49997, upload.NumPersistedItems)
+}
+
+func TestClient_GetFeedUploadStatus_NoUploads(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.GetFeedUploadStatus(context.Background(), testAccount(server.URL), "feed-1")
+	assert.ErrorIs(t, err, whatsapp.ErrNotFound)
+}
@@ -0,0 +1,239 @@
+package whatsapp
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultSyncConcurrency is used when SyncProducts is called with concurrency <= 0
+const defaultSyncConcurrency = 4
+
+// SyncItemResult is the outcome of syncing a single product
+type SyncItemResult struct {
+	RetailerID string
+	ProductID  string // Meta product ID; empty if the sync failed
+	Created    bool   // True if the product was new to the catalog
+	Handle     string // Batch handle, for products imported with the batch API
+	Err        error
+}
+
+// SyncResult aggregates the outcome of SyncProducts
+type SyncResult struct {
+	Created int
+	Updated int
+	Failed  int
+	// Items holds one result per product, in input order. Products skipped
+	// because the context was canceled carry the context error.
+	Items []SyncItemResult
+}
+
+// SyncProducts upserts products into a catalog using up to concurrency
+// parallel workers (see UpsertProduct). A failing product does not stop the
+// others; cancelling ctx stops dispatching new products and returns the
+// partial result with ctx's error. Throttling configured with WithThrottle
+// applies to every request the workers send.
+func (c *Client) SyncProducts(ctx context.Context, account *Account, catalogID string, products []ProductInput, concurrency int) (SyncResult, error) {
+	if concurrency <= 0 {
+		concurrency = defaultSyncConcurrency
+	}
+
+	items := make([]SyncItemResult, len(products))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				id, created, err := c.upsertProduct(ctx, account, catalogID, &products[i])
+				items[i] = SyncItemResult{RetailerID: products[i].RetailerID, ProductID: id, Created: created, Err: err}
+			}
+		}()
+	}
+
+	dispatched := 0
+dispatch:
+	for ; dispatched < len(products); dispatched++ {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- dispatched:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i := dispatched; i < len(products); i++ {
+		items[i] = SyncItemResult{RetailerID: products[i].RetailerID, Err: ctx.Err()}
+	}
+
+	result := SyncResult{Items: items}
+	for _, item := range items {
+		switch {
+		case item.Err != nil:
+			result.Failed++
+		case item.Created:
+			result.Created++
+		default:
+			result.Updated++
+		}
+	}
+
+	c.Log.Info("Product sync finished", "catalog_id", catalogID, "created", result.Created, "updated", result.Updated, "failed", result.Failed)
+	return result, ctx.Err()
+}
+
+// ReconcileResult reports the changes ReconcileCatalog made, by category.
+// Each BatchResult carries the batch handle or the error for that product.
+type ReconcileResult struct {
+	Created   []BatchResult
+	Updated   []BatchResult
+	Deleted   []BatchResult
+	Unchanged []string // Retailer IDs of products that already matched
+	Failed    int      // Number of creates, updates and deletes that failed
+}
+
+// ReconcileOption customizes ReconcileCatalog
+type ReconcileOption func(*reconcileOptions)
+
+type reconcileOptions struct {
+	keepUnlisted bool
+}
+
+// WithoutDeletions keeps catalog products that are missing from the desired
+// state instead of deleting them
+func WithoutDeletions() ReconcileOption {
+	return func(o *reconcileOptions) {
+		o.keepUnlisted = true
+	}
+}
+
+// ReconcileCatalog makes a catalog match the desired products exactly: it
+// lists the current products, diffs them against desired by retailer ID and
+// applies the creates, updates and deletes with the batch API. Products that
+// already match are left alone; products using fields Meta does not report
+// back (such as sale prices, custom labels or variants) are always updated.
+// Products missing from desired are deleted unless WithoutDeletions is given.
+// Invalid or duplicate desired products fail the call before any change is made.
+func (c *Client) ReconcileCatalog(ctx context.Context, account *Account, catalogID string, desired []ProductInput, opts ...ReconcileOption) (ReconcileResult, error) {
+	var options reconcileOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if err := checkDuplicateRetailerIDs(len(desired), func(i int) string { return desired[i].RetailerID }); err != nil {
+		return ReconcileResult{}, err
+	}
+	for i := range desired {
+		if _, err := buildProductBody(&desired[i], false); err != nil {
+			return ReconcileResult{}, fmt.Errorf("product %s: %w", desired[i].RetailerID, err)
+		}
+	}
+
+	current, err := c.ListCatalogProducts(ctx, account, catalogID)
+	if err != nil {
+		return ReconcileResult{}, fmt.Errorf("failed to list catalog products: %w", err)
+	}
+	existing := make(map[string]*ProductInfo, len(current))
+	for i := range current {
+		existing[current[i].RetailerID] = &current[i]
+	}
+
+	var result ReconcileResult
+	var upserts []ProductInput
+	var created []bool // Whether each upsert is a create
+	wanted := make(map[string]bool, len(desired))
+	for i := range desired {
+		product := &desired[i]
+		wanted[product.RetailerID] = true
+		info, ok := existing[product.RetailerID]
+		if ok && productMatches(info, product) {
+			result.Unchanged = append(result.Unchanged, product.RetailerID)
+			continue
+		}
+		upserts = append(upserts, *product)
+		created = append(created, !ok)
+	}
+
+	var deletes []string
+	if !options.keepUnlisted {
+		for i := range current {
+			if retailerID := current[i].RetailerID; retailerID != "" && !wanted[retailerID] {
+				deletes = append(deletes, retailerID)
+			}
+		}
+	}
+
+	if len(upserts) > 0 {
+		results, err := c.BatchUpsertProducts(ctx, account, catalogID, upserts)
+		for i, r := range results {
+			if created[i] {
+				result.Created = append(result.Created, r)
+			} else {
+				result.Updated = append(result.Updated, r)
+			}
+		}
+		if err != nil {
+			result.count()
+			return result, err
+		}
+	}
+	if len(deletes) > 0 {
+		results, err := c.BatchDeleteProducts(ctx, account, catalogID, deletes)
+		result.Deleted = results
+		if err != nil {
+			result.count()
+			return result, err
+		}
+	}
+
+	result.count()
+	c.Log.Info("Catalog reconciled", "catalog_id", catalogID, "created", len(result.Created), "updated", len(result.Updated),
+		"deleted", len(result.Deleted), "unchanged", len(result.Unchanged), "failed", result.Failed)
+	return result, nil
+}
+
+// count sets Failed from the per-product results
+func (r *ReconcileResult) count() {
+	r.Failed = 0
+	for _, results := range [][]BatchResult{r.Created, r.Updated, r.Deleted} {
+		for _, result := range results {
+			if result.Err != nil {
+				r.Failed++
+			}
+		}
+	}
+}
+
+// productMatches reports whether a catalog product already has the desired
+// fields. It errs on the side of reporting a change, which only costs a
+// redundant update.
+func productMatches(info *ProductInfo, product *ProductInput) bool {
+	// These fields are not read back, so a change to them cannot be detected
+	if product.ImageHandle != "" || len(product.Variants) > 0 || product.CustomLabels != [5]string{} ||
+		len(product.CustomData) > 0 || product.SalePrice != 0 {
+		return false
+	}
+
+	if info.Name != product.Name || info.Description != product.Description || info.URL != product.URL ||
+		info.ImageURL != product.ImageURL || info.RetailerProductGroupID != product.RetailerProductGroupID ||
+		!slices.Equal(info.AdditionalImageURLs, product.AdditionalImageURLs) ||
+		!strings.EqualFold(info.Currency, string(product.Currency)) {
+		return false
+	}
+	if product.Availability != "" && info.Availability != string(product.Availability) {
+		return false
+	}
+	if product.Condition != "" && info.Condition != product.Condition {
+		return false
+	}
+
+	minorUnits, err := ParsePrice(plainPrice(info.Price), string(product.Currency))
+	return err == nil && minorUnits == product.Price
+}
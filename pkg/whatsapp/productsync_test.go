@@ -0,0 +1,196 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSyncServer serves a catalog where only SKU-1 exists (as prod-1) and
+// counts the products created
+func newSyncServer(t *testing.T, created *int) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			data := []map[string]string{}
+			if r.URL.Query().Get("filter") == `{"retailer_id":{"eq":"SKU-1"}}` {
+				data = append(data, map[string]string{"id": "prod-1", "retailer_id": "SKU-1"})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Path == "/v21.0/catalog-1/products" {
+			*created++
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"id": "prod-new", "success": true})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_SyncProducts(t *testing.T) {
+	t.Parallel()
+
+	var created int
+	server := newSyncServer(t, &created)
+	client := newTestClient(t, server)
+
+	products := []whatsapp.ProductInput{
+		{Name: "Existing", Price: 100, Currency: "USD", RetailerID: "SKU-1"},
+		{Name: "New", Price: 100, Currency: "USD", RetailerID: "SKU-2"},
+		{Name: "Bad currency", Price: 100, Currency: "US", RetailerID: "SKU-3"},
+		{Name: "New too", Price: 100, Currency: "EUR", RetailerID: "SKU-4"},
+	}
+
+	result, err := client.SyncProducts(context.Background(), testAccount(server.URL), "catalog-1", products, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Failed)
+	assert.Equal(t, 2, created)
+
+	require.Len(t, result.Items, 4)
+	assert.Equal(t, "prod-1", result.Items[0].ProductID)
+	assert.False(t, result.Items[0].Created)
+	assert.True(t, result.Items[1].Created)
+	assert.Equal(t, "SKU-3", result.Items[2].RetailerID)
+	assert.Error(t, result.Items[2].Err)
+}
+
+func TestClient_SyncProducts_Canceled(t *testing.T) {
+	t.Parallel()
+
+	var created int
+	server := newSyncServer(t, &created)
+	client := newTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	products := []whatsapp.ProductInput{
+		{Name: "A", Price: 100, Currency: "USD", RetailerID: "SKU-2"},
+		{Name: "B", Price: 100, Currency: "USD", RetailerID: "SKU-3"},
+	}
+
+	result, err := client.SyncProducts(ctx, testAccount(server.URL), "catalog-1", products, 0)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, result.Failed)
+	assert.ErrorIs(t, result.Items[1].Err, context.Canceled)
+	assert.Zero(t, created)
+}
+
+// newReconcileServer serves a catalog holding SKU-1, SKU-2 and SKU-9 and
+// records the batch item requests it receives
+func newReconcileServer(t *testing.T, batches *[][]map[string]interface{}) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{
+					{"id": "prod-1", "retailer_id": "SKU-1", "name": "Mug", "price": "$12.99", "currency": "USD",
+						"url": "https://example.com/mug", "image_url": "https://example.com/mug.jpg", "availability": "in stock"},
+					{"id": "prod-2", "retailer_id": "SKU-2", "name": "Lamp", "price": "$20.00", "currency": "USD",
+						"url": "https://example.com/lamp", "image_url": "https://example.com/lamp.jpg"},
+					{"id": "prod-9", "retailer_id": "SKU-9", "name": "Discontinued", "price": "$1.00", "currency": "USD"},
+				},
+			})
+			return
+		}
+
+		var body struct {
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		*batches = append(*batches, body.Requests)
+		mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"handles": []string{"handle-1"}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_ReconcileCatalog(t *testing.T) {
+	t.Parallel()
+
+	desired := []whatsapp.ProductInput{
+		{Name: "Mug", Price: 1299, Currency: "USD", RetailerID: "SKU-1", URL: "https://example.com/mug",
+			ImageURL: "https://example.com/mug.jpg", Availability: whatsapp.ProductAvailabilityInStock},
+		{Name: "Lamp", Price: 2500, Currency: "USD", RetailerID: "SKU-2", URL: "https://example.com/lamp",
+			ImageURL: "https://example.com/lamp.jpg"},
+		{Name: "Rug", Price: 4000, Currency: "USD", RetailerID: "SKU-3", URL: "https://example.com/rug",
+			ImageURL: "https://example.com/rug.jpg"},
+	}
+
+	var batches [][]map[string]interface{}
+	server := newReconcileServer(t, &batches)
+	client := newTestClient(t, server)
+
+	result, err := client.ReconcileCatalog(context.Background(), testAccount(server.URL), "catalog-1", desired)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SKU-1"}, result.Unchanged)
+	require.Len(t, result.Updated, 1)
+	assert.Equal(t, "SKU-2", result.Updated[0].RetailerID)
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, "SKU-3", result.Created[0].RetailerID)
+	require.Len(t, result.Deleted, 1)
+	assert.Equal(t, "SKU-9", result.Deleted[0].RetailerID)
+	assert.Equal(t, "handle-1", result.Deleted[0].Handle)
+	assert.Zero(t, result.Failed)
+
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 2)
+	assert.Equal(t, "SKU-2", batches[0][0]["retailer_id"])
+	assert.Equal(t, "SKU-3", batches[0][1]["retailer_id"])
+	assert.Equal(t, []map[string]interface{}{{"method": "DELETE", "retailer_id": "SKU-9"}}, batches[1])
+}
+
+func TestClient_ReconcileCatalog_WithoutDeletions(t *testing.T) {
+	t.Parallel()
+
+	var batches [][]map[string]interface{}
+	server := newReconcileServer(t, &batches)
+	client := newTestClient(t, server)
+
+	desired := []whatsapp.ProductInput{
+		{Name: "Rug", Price: 4000, Currency: "USD", RetailerID: "SKU-3", URL: "https://example.com/rug",
+			ImageURL: "https://example.com/rug.jpg"},
+	}
+	result, err := client.ReconcileCatalog(context.Background(), testAccount(server.URL), "catalog-1", desired, whatsapp.WithoutDeletions())
+	require.NoError(t, err)
+	assert.Len(t, result.Created, 1)
+	assert.Empty(t, result.Deleted)
+	assert.Len(t, batches, 1)
+}
+
+func TestClient_ReconcileCatalog_InvalidDesired(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid desired state should not reach the API")
+	}))
+	defer server.Close()
+	client := newTestClient(t, server)
+
+	desired := []whatsapp.ProductInput{
+		{Name: "Mug", Price: 1299, Currency: "USD", RetailerID: "SKU-1"},
+		{Name: "Mug again", Price: 1299, Currency: "USD", RetailerID: "SKU-1"},
+	}
+	_, err := client.ReconcileCatalog(context.Background(), testAccount(server.URL), "catalog-1", desired)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate retailer ID")
+}
@@ -0,0 +1,131 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RateLimitStatus holds the most recent API usage reported by Meta.
+// Usage values are percentages of the allowed quota (0-100).
+type RateLimitStatus struct {
+	AppCallCount    int // X-App-Usage call_count
+	AppTotalCPUTime int // X-App-Usage total_cputime
+	AppTotalTime    int // X-App-Usage total_time
+	// BusinessUseCase is the highest usage across all X-Business-Use-Case-Usage entries
+	BusinessUseCase int
+	// EstimatedTimeToRegainAccess is set by Meta once a business use case is throttled
+	EstimatedTimeToRegainAccess time.Duration
+	UpdatedAt                   time.Time
+}
+
+// MaxUsage returns the highest usage percentage across all reported metrics
+func (s RateLimitStatus) MaxUsage() int {
+	return max(s.AppCallCount, s.AppTotalCPUTime, s.AppTotalTime, s.BusinessUseCase)
+}
+
+// ThrottleConfig enables proactive throttling based on Meta's usage headers.
+// The zero value disables throttling.
+type ThrottleConfig struct {
+	Threshold int           // Usage percentage at which requests are delayed; 0 disables throttling
+	Pause     time.Duration // Delay used when Meta provides no estimated time to regain access
+}
+
+// defaultThrottlePause is used when ThrottleConfig.Pause is not set
+const defaultThrottlePause = time.Second
+
+// appUsage represents the X-App-Usage header
+type appUsage struct {
+	CallCount    int `json:"call_count"`
+	TotalCPUTime int `json:"total_cputime"`
+	TotalTime    int `json:"total_time"`
+}
+
+// businessUseCaseUsage represents a single entry in the X-Business-Use-Case-Usage header
+type businessUseCaseUsage struct {
+	Type                        string `json:"type"`
+	CallCount                   int    `json:"call_count"`
+	TotalCPUTime                int    `json:"total_cputime"`
+	TotalTime                   int    `json:"total_time"`
+	EstimatedTimeToRegainAccess int    `json:"estimated_time_to_regain_access"` // Minutes
+}
+
+// RateLimitStatus returns the usage reported by the most recent Meta API response
+func (c *Client) RateLimitStatus() RateLimitStatus {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateStatus
+}
+
+// updateRateLimit records the usage headers from a Meta API response
+func (c *Client) updateRateLimit(header http.Header) {
+	appHeader := header.Get("X-App-Usage")
+	bucHeader := header.Get("X-Business-Use-Case-Usage")
+	if appHeader == "" && bucHeader == "" {
+		return
+	}
+
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+
+	status := c.rateStatus
+	if appHeader != "" {
+		var usage appUsage
+		if err := json.Unmarshal([]byte(appHeader), &usage); err == nil {
+			status.AppCallCount = usage.CallCount
+			status.AppTotalCPUTime = usage.TotalCPUTime
+			status.AppTotalTime = usage.TotalTime
+		}
+	}
+	if bucHeader != "" {
+		// Keyed by business ID, each holding a list of use case usages
+		var usage map[string][]businessUseCaseUsage
+		if err := json.Unmarshal([]byte(bucHeader), &usage); err == nil {
+			status.BusinessUseCase = 0
+			status.EstimatedTimeToRegainAccess = 0
+			for _, entries := range usage {
+				for _, e := range entries {
+					status.BusinessUseCase = max(status.BusinessUseCase, e.CallCount, e.TotalCPUTime, e.TotalTime)
+					regain := time.Duration(e.EstimatedTimeToRegainAccess) * time.Minute
+					status.EstimatedTimeToRegainAccess = max(status.EstimatedTimeToRegainAccess, regain)
+				}
+			}
+		}
+	}
+	status.UpdatedAt = time.Now()
+	c.rateStatus = status
+}
+
+// waitForRateLimit delays the next request when throttling is enabled and the
+// last reported usage has crossed the configured threshold
+func (c *Client) waitForRateLimit(ctx context.Context) error {
+	if c.Throttle.Threshold <= 0 {
+		return nil
+	}
+
+	status := c.RateLimitStatus()
+	if status.MaxUsage() < c.Throttle.Threshold {
+		return nil
+	}
+
+	pause := status.EstimatedTimeToRegainAccess
+	if pause <= 0 {
+		pause = c.Throttle.Pause
+	}
+	if pause <= 0 {
+		pause = defaultThrottlePause
+	}
+
+	c.Log.Warn("Throttling Meta API request", "usage", status.MaxUsage(), "threshold", c.Throttle.Threshold, "pause", pause)
+
+	timer := time.NewTimer(pause)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("request canceled while throttled: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,89 @@
+package whatsapp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_RateLimitStatus_ParsesHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Usage", `{"call_count":28,"total_cputime":25,"total_time":12}`)
+		w.Header().Set("X-Business-Use-Case-Usage", `{"987654321":[{"type":"whatsapp_business_management","call_count":91,"total_cputime":10,"total_time":20,"estimated_time_to_regain_access":2}]}`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	assert.Zero(t, client.RateLimitStatus().MaxUsage())
+
+	_, err := client.ListCatalogs(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+
+	status := client.RateLimitStatus()
+	assert.Equal(t, 28, status.AppCallCount)
+	assert.Equal(t, 25, status.AppTotalCPUTime)
+	assert.Equal(t, 12, status.AppTotalTime)
+	assert.Equal(t, 91, status.BusinessUseCase)
+	assert.Equal(t, 2*time.Minute, status.EstimatedTimeToRegainAccess)
+	assert.Equal(t, 91, status.MaxUsage())
+	assert.False(t, status.UpdatedAt.IsZero())
+}
+
+func TestClient_Throttle_DelaysAboveThreshold(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Usage", `{"call_count":95,"total_cputime":0,"total_time":0}`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	client.Throttle = whatsapp.ThrottleConfig{Threshold: 90, Pause: 50 * time.Millisecond}
+	account := testAccount(server.URL)
+
+	// First request has no usage data yet and is not delayed
+	_, err := client.ListCatalogs(context.Background(), account)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.ListCatalogs(context.Background(), account)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+}
+
+func TestClient_Throttle_CanceledContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-App-Usage", `{"call_count":100,"total_cputime":0,"total_time":0}`)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	client.Throttle = whatsapp.ThrottleConfig{Threshold: 90, Pause: time.Minute}
+	account := testAccount(server.URL)
+
+	_, err := client.ListCatalogs(context.Background(), account)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.ListCatalogs(ctx, account)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,102 @@
+package whatsapp
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// RetryConfig controls retrying of transient Meta API failures.
+// The zero value disables retries.
+//
+// GET, PUT and DELETE requests are retried on any transient failure. POST
+// requests, such as sending a message or a batch of product updates, are
+// retried only when Meta rejected them for rate limiting: after a 5xx Meta
+// may already have acted on the request, and a retry could send a customer
+// the same message twice.
+type RetryConfig struct {
+	MaxAttempts int           // Total attempts including the first one; <= 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled on each further attempt
+	MaxDelay    time.Duration // Upper bound for a single delay; 0 means no bound
+}
+
+// DefaultRetryConfig is a sensible retry policy for batch and sync workloads
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+// transientErrorCodes are Graph API error codes that indicate a temporary
+// condition (outage or throttling) where retrying later can succeed
+var transientErrorCodes = map[int]bool{
+	1:      true, // API unknown
+	2:      true, // API service temporarily unavailable
+	4:      true, // Application request limit reached
+	17:     true, // User request limit reached
+	341:    true, // Application limit reached
+	613:    true, // Calls within one hour exceeded
+	80004:  true, // Too many calls to this WhatsApp Business account
+	130429: true, // Cloud API throughput limit reached
+}
+
+// throttlingErrorCodes are the transientErrorCodes for rate limiting, which
+// Meta reports without acting on the request
+var throttlingErrorCodes = map[int]bool{
+	4:      true,
+	17:     true,
+	341:    true,
+	613:    true,
+	80004:  true,
+	130429: true,
+}
+
+// isIdempotentMethod reports whether repeating a request with the method has
+// the same effect as sending it once
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryableFor reports whether a request with the given method that failed
+// with e may be retried; see RetryConfig
+func (e *GraphAPIError) retryableFor(method string) bool {
+	if isIdempotentMethod(method) {
+		return e.Retryable()
+	}
+	return e.HTTPStatus == http.StatusTooManyRequests || throttlingErrorCodes[e.Code]
+}
+
+// isRetryableStatus reports whether an HTTP status code is worth retrying
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable:
+		return true
+	}
+	return false
+}
+
+// backoff returns the jittered delay to wait before the given retry attempt (1-based)
+func (r RetryConfig) backoff(attempt int) time.Duration {
+	delay := r.BaseDelay
+	if delay <= 0 {
+		delay = DefaultRetryConfig.BaseDelay
+	}
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if r.MaxDelay > 0 && delay >= r.MaxDelay {
+			break
+		}
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+
+	// Keep half the delay fixed and randomize the other half
+	half := delay / 2
+	return half + rand.N(half+1)
+}
@@ -0,0 +1,186 @@
+package whatsapp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRetryTestClient(t *testing.T, server *httptest.Server) *whatsapp.Client {
+	t.Helper()
+	client := newTestClient(t, server)
+	client.Retry = whatsapp.RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    5 * time.Millisecond,
+	}
+	return client
+}
+
+func TestClient_Retry_TransientStatusThenSuccess(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"catalog-123"}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	catalog, err := client.GetCatalog(context.Background(), testAccount(server.URL), "catalog-123")
+	require.NoError(t, err)
+	assert.Equal(t, "catalog-123", catalog.ID)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_Retry_TransientErrorCode(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Business use case rate limit is reported as a 400 with code 80004
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"Too many calls","code":80004}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"catalog-123"}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	_, err := client.CreateCatalog(context.Background(), testAccount(server.URL), "My Catalog")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_Retry_PostNotRetriedOnServerError(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	// Meta may have sent the message before failing, so a retry could duplicate it
+	_, err := client.SendTextMessage(context.Background(), testAccount(server.URL), "1234567890", "Hello")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_Retry_PostRetriedWhenThrottled(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"Cloud API throughput limit reached","code":130429}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"messages":[{"id":"wamid.sent"}]}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	msgID, err := client.SendTextMessage(context.Background(), testAccount(server.URL), "1234567890", "Hello")
+	require.NoError(t, err)
+	assert.Equal(t, "wamid.sent", msgID)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_Retry_DoesNotRetryClientErrors(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","code":100}}`))
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	_, err := client.CreateCatalog(context.Background(), testAccount(server.URL), "My Catalog")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.NotContains(t, err.Error(), "attempts")
+}
+
+func TestClient_Retry_ExhaustedReportsAttempts(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(t, server)
+
+	_, err := client.GetCatalog(context.Background(), testAccount(server.URL), "catalog-123")
+	require.Error(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Contains(t, err.Error(), "after 3 attempts")
+}
+
+func TestClient_Retry_StopsOnContextCancel(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	client.Retry = whatsapp.RetryConfig{MaxAttempts: 5, BaseDelay: time.Minute}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetCatalog(ctx, testAccount(server.URL), "catalog-123")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestClient_Retry_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+
+	_, err := client.CreateCatalog(context.Background(), testAccount(server.URL), "My Catalog")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,34 @@
+package whatsapp
+
+import (
+	"net/url"
+	"strings"
+)
+
+// EmbeddedSignupDialogURL is the Facebook Login dialog used for embedded signup
+const EmbeddedSignupDialogURL = "https://www.facebook.com/dialog/oauth"
+
+// Permissions requested when a tenant grants access to their WABA and catalogs
+const (
+	ScopeWhatsAppBusinessManagement = "whatsapp_business_management"
+	ScopeCatalogManagement          = "catalog_management"
+)
+
+// embeddedSignupScopes are the permissions requested by BuildEmbeddedSignupURL
+var embeddedSignupScopes = []string{ScopeWhatsAppBusinessManagement, ScopeCatalogManagement}
+
+// BuildEmbeddedSignupURL returns the OAuth dialog URL that starts embedded signup
+// for the given Facebook Login for Business configuration. The dialog redirects to
+// redirectURI with an authorization code and the unchanged state.
+func BuildEmbeddedSignupURL(appID, configID, redirectURI, state string) string {
+	params := url.Values{}
+	params.Set("client_id", appID)
+	params.Set("config_id", configID)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("state", state)
+	params.Set("response_type", "code")
+	params.Set("override_default_response_type", "true")
+	params.Set("scope", strings.Join(embeddedSignupScopes, ","))
+
+	return EmbeddedSignupDialogURL + "?" + params.Encode()
+}
@@ -0,0 +1,31 @@
+package whatsapp_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEmbeddedSignupURL(t *testing.T) {
+	t.Parallel()
+
+	raw := whatsapp.BuildEmbeddedSignupURL("app-123", "cfg-456", "https://app.example.com/onboard/callback?tenant=a b", "st&ate")
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "https", u.Scheme)
+	assert.Equal(t, "www.facebook.com", u.Host)
+	assert.Equal(t, "/dialog/oauth", u.Path)
+
+	q := u.Query()
+	assert.Equal(t, "app-123", q.Get("client_id"))
+	assert.Equal(t, "cfg-456", q.Get("config_id"))
+	assert.Equal(t, "https://app.example.com/onboard/callback?tenant=a b", q.Get("redirect_uri"))
+	assert.Equal(t, "st&ate", q.Get("state"))
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "true", q.Get("override_default_response_type"))
+	assert.Equal(t, "whatsapp_business_management,catalog_management", q.Get("scope"))
+}
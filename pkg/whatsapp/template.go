@@ -3,8 +3,11 @@ package whatsapp
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -23,13 +26,51 @@ type TemplateSubmission struct {
 	SampleValues    []interface{} // For named: [{param_name: "name", value: "John"}, ...]
 }
 
+// maxTemplateNameLength is the longest template name Meta accepts
+const maxTemplateNameLength = 512
+
+// validTemplateCategories are the categories Meta accepts for new templates
+var validTemplateCategories = map[string]bool{
+	"MARKETING":      true,
+	"UTILITY":        true,
+	"AUTHENTICATION": true,
+}
+
+// ValidateTemplateName checks that a template name only contains lowercase
+// letters, digits and underscores, as required by Meta
+func ValidateTemplateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if len(name) > maxTemplateNameLength {
+		return fmt.Errorf("template name exceeds %d characters", maxTemplateNameLength)
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return fmt.Errorf("invalid template name %q: only lowercase letters, digits and underscores are allowed", name)
+		}
+	}
+	return nil
+}
+
 // SubmitTemplate submits a template to Meta's API (creates new or updates existing)
 func (c *Client) SubmitTemplate(ctx context.Context, account *Account, template *TemplateSubmission) (string, error) {
 	// If MetaTemplateID is set, this is an update to existing template
 	isUpdate := template.MetaTemplateID != ""
+
+	// Name and category are immutable, so they are only checked on create
+	if !isUpdate {
+		if err := ValidateTemplateName(template.Name); err != nil {
+			return "", err
+		}
+		if !validTemplateCategories[template.Category] {
+			return "", fmt.Errorf("invalid template category %q: must be MARKETING, UTILITY or AUTHENTICATION", template.Category)
+		}
+	}
+
 	var url string
 	if isUpdate {
-		url = fmt.Sprintf("%s/%s", c.baseURL, template.MetaTemplateID)
+		url = fmt.Sprintf("%s/%s", c.getBaseURL(), template.MetaTemplateID)
 	} else {
 		url = c.buildTemplatesURL(account)
 	}
@@ -219,7 +260,7 @@ func (c *Client) SubmitTemplate(ctx context.Context, account *Account, template
 	payloadJSON, _ := json.MarshalIndent(payload, "", "  ")
 	c.Log.Info(action+" template to Meta", "url", url, "name", template.Name, "payload", string(payloadJSON))
 
-	respBody, err := c.doRequest(ctx, http.MethodPost, url, payload, account.AccessToken)
+	respBody, err := c.doRequest(ctx, http.MethodPost, url, payload, account)
 	if err != nil {
 		c.Log.Error("Failed to "+action+" template", "error", err, "name", template.Name)
 		return "", err
@@ -240,31 +281,43 @@ func (c *Client) SubmitTemplate(ctx context.Context, account *Account, template
 	return result.ID, nil
 }
 
-// FetchTemplates fetches all templates from Meta's API
-func (c *Client) FetchTemplates(ctx context.Context, account *Account) ([]MetaTemplate, error) {
-	url := fmt.Sprintf("%s?limit=100", c.buildTemplatesURL(account))
+const (
+	// templateFields are the template fields requested when listing templates
+	templateFields = "id,name,status,category,language,components"
+	// templateListPageLimit is the page size used when fetching all templates
+	templateListPageLimit = 100
+)
 
-	respBody, err := c.doRequest(ctx, http.MethodGet, url, nil, account.AccessToken)
+// FetchTemplates fetches all templates from Meta's API, following pagination
+// until the last page
+func (c *Client) FetchTemplates(ctx context.Context, account *Account) ([]MetaTemplate, error) {
+	params := url.Values{}
+	params.Add("fields", templateFields)
+	params.Add("limit", strconv.Itoa(templateListPageLimit))
+	templates, err := listAll[MetaTemplate](ctx, c, account, c.buildTemplatesURL(account), params)
 	if err != nil {
 		c.Log.Error("Failed to fetch templates", "error", err)
 		return nil, err
 	}
 
-	var result TemplateListResponse
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	c.Log.Info("Fetched templates from Meta", "count", len(result.Data))
-	return result.Data, nil
+	c.Log.Info("Fetched templates from Meta", "count", len(templates))
+	return templates, nil
 }
 
-// DeleteTemplate deletes a template from Meta's API
+// ErrTemplateNotFound is returned when deleting a template that does not exist.
+// It also matches ErrNotFound.
+var ErrTemplateNotFound = fmt.Errorf("template %w", ErrNotFound)
+
+// DeleteTemplate deletes a template from Meta's API.
+// Meta deletes every language variant of the named template.
 func (c *Client) DeleteTemplate(ctx context.Context, account *Account, templateName string) error {
-	url := fmt.Sprintf("%s?name=%s", c.buildTemplatesURL(account), templateName)
+	apiURL := fmt.Sprintf("%s?name=%s", c.buildTemplatesURL(account), url.QueryEscape(templateName))
 
-	_, err := c.doRequest(ctx, http.MethodDelete, url, nil, account.AccessToken)
+	_, err := c.doRequest(ctx, http.MethodDelete, apiURL, nil, account)
 	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("%w: %s", ErrTemplateNotFound, templateName)
+		}
 		c.Log.Error("Failed to delete template", "error", err, "template", templateName)
 		return err
 	}
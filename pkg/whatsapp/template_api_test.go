@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -135,7 +136,7 @@ func TestClient_SubmitTemplate_APIError(t *testing.T) {
 	ctx := context.Background()
 
 	tmpl := &whatsapp.TemplateSubmission{
-		Name:        "",
+		Name:        "hello",
 		Language:    "en",
 		Category:    "MARKETING",
 		BodyContent: "Hello",
@@ -146,6 +147,47 @@ func TestClient_SubmitTemplate_APIError(t *testing.T) {
 	assert.Contains(t, err.Error(), "Invalid template name")
 }
 
+func TestClient_SubmitTemplate_InvalidName(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.NewWithTimeout(testutil.NopLogger(), 5*time.Second)
+	account := testAccount("")
+
+	for _, name := range []string{"", "Hello", "order-update", "order update", strings.Repeat("a", 513)} {
+		tmpl := &whatsapp.TemplateSubmission{
+			Name:        name,
+			Language:    "en",
+			Category:    "MARKETING",
+			BodyContent: "Hello",
+		}
+		_, err := client.SubmitTemplate(context.Background(), account, tmpl)
+		assert.Error(t, err, "name %q", name)
+	}
+}
+
+func TestClient_SubmitTemplate_InvalidCategory(t *testing.T) {
+	t.Parallel()
+
+	client := whatsapp.NewWithTimeout(testutil.NopLogger(), 5*time.Second)
+	tmpl := &whatsapp.TemplateSubmission{
+		Name:        "hello",
+		Language:    "en",
+		Category:    "PROMOTIONAL",
+		BodyContent: "Hello",
+	}
+
+	_, err := client.SubmitTemplate(context.Background(), testAccount(""), tmpl)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid template category")
+}
+
+func TestValidateTemplateName(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, whatsapp.ValidateTemplateName("order_update_2"))
+	assert.Error(t, whatsapp.ValidateTemplateName("Order_Update"))
+}
+
 func TestClient_SubmitTemplate_MissingVariableSamples(t *testing.T) {
 	t.Parallel()
 
@@ -203,6 +245,43 @@ func TestClient_FetchTemplates_Success(t *testing.T) {
 	assert.Equal(t, "goodbye", templates[1].Name)
 }
 
+func TestClient_FetchTemplates_Pagination(t *testing.T) {
+	t.Parallel()
+
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Query().Get("fields"), "components")
+		cursor := r.URL.Query().Get("after")
+		cursors = append(cursors, cursor)
+
+		w.WriteHeader(http.StatusOK)
+		if cursor == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]interface{}{{"id": "1", "name": "hello", "status": "APPROVED"}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "page2"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]interface{}{{"id": "2", "name": "goodbye", "status": "PENDING"}},
+			"paging": map[string]interface{}{
+				"cursors": map[string]string{"after": "page3"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	templates, err := client.FetchTemplates(context.Background(), testAccount(server.URL))
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "goodbye", templates[1].Name)
+	assert.Equal(t, []string{"", "page2"}, cursors)
+}
+
 func TestClient_FetchTemplates_Empty(t *testing.T) {
 	t.Parallel()
 
@@ -302,4 +381,51 @@ func TestClient_DeleteTemplate_APIError(t *testing.T) {
 
 	err := client.DeleteTemplate(context.Background(), account, "nonexistent")
 	require.Error(t, err)
+	assert.ErrorIs(t, err, whatsapp.ErrTemplateNotFound)
+}
+
+func TestClient_DeleteTemplate_ObjectMissing(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Unsupported delete request. Object with ID 'waba-123' does not exist, cannot be loaded due to missing permissions, or does not support this operation.","type":"GraphMethodException","code":100,"error_subcode":33,"fbtrace_id":"AbCdEf"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.DeleteTemplate(context.Background(), testAccount(server.URL), "nonexistent")
+	require.ErrorIs(t, err, whatsapp.ErrTemplateNotFound)
+	assert.Contains(t, err.Error(), "nonexistent")
+}
+
+func TestClient_DeleteTemplate_MessageMentionsNotFound(t *testing.T) {
+	t.Parallel()
+
+	// Only the error code decides; a message that happens to say "not found" does not
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100,"error_user_msg":"Parameter name not found in request","fbtrace_id":"AbCdEf"}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.DeleteTemplate(context.Background(), testAccount(server.URL), "hello_world")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, whatsapp.ErrTemplateNotFound)
+}
+
+func TestClient_DeleteTemplate_OtherError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid OAuth access token","code":190}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	err := client.DeleteTemplate(context.Background(), testAccount(server.URL), "hello_world")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, whatsapp.ErrTemplateNotFound)
 }
@@ -0,0 +1,121 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// tokenExpiredCode is the Graph API error code for an invalid or expired access token
+const tokenExpiredCode = 190
+
+// tokenExpirySkew is how long before its expiry a token is refreshed, so a
+// request does not carry a token that expires in flight
+const tokenExpirySkew = time.Minute
+
+// TokenProvider supplies access tokens for an Account, e.g. from a secrets
+// store or an OAuth refresh flow
+type TokenProvider interface {
+	// Token returns an access token and its expiry (zero if unknown).
+	// refresh is true after Meta rejected the previous token, or when the
+	// returned token expires within a minute; the provider must then return
+	// a new token rather than a cached one.
+	Token(ctx context.Context, refresh bool) (string, time.Time, error)
+}
+
+// StaticToken is a TokenProvider that always returns the same token
+type StaticToken string
+
+// Token implements TokenProvider
+func (t StaticToken) Token(context.Context, bool) (string, time.Time, error) {
+	return string(t), time.Time{}, nil
+}
+
+// accessToken returns the token to use for the account's requests. The
+// TokenProvider takes precedence; otherwise AccessToken is used as is.
+func (a *Account) accessToken(ctx context.Context, refresh bool) (string, error) {
+	provider := a.TokenProvider
+	if provider == nil {
+		provider = StaticToken(a.AccessToken)
+	}
+
+	token, expiry, err := provider.Token(ctx, refresh)
+	if err == nil && !refresh && !expiry.IsZero() && time.Until(expiry) < tokenExpirySkew {
+		// Refresh ahead of expiry rather than spend a request on a rejected token
+		token, _, err = provider.Token(ctx, true)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return token, nil
+}
+
+// isTokenExpired reports whether err is Meta rejecting the access token
+func isTokenExpired(err error) bool {
+	var apiErr *GraphAPIError
+	return errors.As(err, &apiErr) && apiErr.Code == tokenExpiredCode
+}
+
+// TokenResponse is an access token issued by the OAuth token endpoint
+type TokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"` // Seconds until expiry; 0 if the token does not expire
+}
+
+// ExchangeCodeForToken exchanges the authorization code returned by embedded
+// signup for a business access token. redirectURI must match the one used to
+// start the dialog, or be empty when the code came from the JavaScript SDK.
+func (c *Client) ExchangeCodeForToken(ctx context.Context, appID, appSecret, code, redirectURI string) (*TokenResponse, error) {
+	params := url.Values{}
+	params.Set("client_id", appID)
+	params.Set("client_secret", appSecret)
+	params.Set("code", code)
+	if redirectURI != "" {
+		params.Set("redirect_uri", redirectURI)
+	}
+
+	token, err := c.requestToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
+	}
+	return token, nil
+}
+
+// ExtendToken exchanges a short-lived user access token for a long-lived one
+func (c *Client) ExtendToken(ctx context.Context, appID, appSecret, shortToken string) (*TokenResponse, error) {
+	params := url.Values{}
+	params.Set("grant_type", "fb_exchange_token")
+	params.Set("client_id", appID)
+	params.Set("client_secret", appSecret)
+	params.Set("fb_exchange_token", shortToken)
+
+	token, err := c.requestToken(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extend token: %w", err)
+	}
+	return token, nil
+}
+
+// requestToken calls the OAuth token endpoint with app credentials in the query
+func (c *Client) requestToken(ctx context.Context, params url.Values) (*TokenResponse, error) {
+	tokenURL := fmt.Sprintf("%s/oauth/access_token?%s", c.getBaseURL(), params.Encode())
+
+	respBody, err := c.doRequest(ctx, http.MethodGet, tokenURL, nil, &Account{})
+	if err != nil {
+		return nil, err
+	}
+
+	var token TokenResponse
+	if err := json.Unmarshal(respBody, &token); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access_token")
+	}
+	return &token, nil
+}
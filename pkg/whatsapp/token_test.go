@@ -0,0 +1,236 @@
+package whatsapp_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rotatingTokenProvider hands out "token-1", "token-2", ... and records refreshes
+type rotatingTokenProvider struct {
+	issued    atomic.Int32
+	refreshes atomic.Int32
+	err       error
+}
+
+func (p *rotatingTokenProvider) Token(_ context.Context, refresh bool) (string, time.Time, error) {
+	if p.err != nil {
+		return "", time.Time{}, p.err
+	}
+	if refresh {
+		p.refreshes.Add(1)
+		p.issued.Add(1)
+	}
+	if p.issued.Load() == 0 {
+		p.issued.Store(1)
+	}
+	return "token-" + strconv.Itoa(int(p.issued.Load())), time.Time{}, nil
+}
+
+// newExpiringTokenServer rejects every token except valid with Graph API code 190
+func newExpiringTokenServer(t *testing.T, valid string, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("Authorization") != "Bearer "+valid {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"Error validating access token","type":"OAuthException","code":190}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+}
+
+func TestClient_TokenProvider_RefreshesExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := newExpiringTokenServer(t, "token-2", &calls)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{}
+	account := testAccount(server.URL)
+	account.TokenProvider = provider
+
+	err := newTestClient(t, server).MarkMessageRead(context.Background(), account, "wamid.1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(1), provider.refreshes.Load())
+}
+
+// expiringTokenProvider hands out a token that is about to expire until asked to refresh
+type expiringTokenProvider struct {
+	refreshes atomic.Int32
+}
+
+func (p *expiringTokenProvider) Token(_ context.Context, refresh bool) (string, time.Time, error) {
+	if refresh {
+		p.refreshes.Add(1)
+		return "fresh-token", time.Now().Add(time.Hour), nil
+	}
+	return "stale-token", time.Now().Add(10 * time.Second), nil
+}
+
+func TestClient_TokenProvider_RefreshesBeforeExpiry(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := newExpiringTokenServer(t, "fresh-token", &calls)
+	defer server.Close()
+
+	provider := &expiringTokenProvider{}
+	account := testAccount(server.URL)
+	account.TokenProvider = provider
+
+	err := newTestClient(t, server).MarkMessageRead(context.Background(), account, "wamid.1")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load(), "the expiring token should not be sent")
+	assert.Equal(t, int32(1), provider.refreshes.Load())
+}
+
+func TestClient_TokenProvider_RefreshesOnlyOnce(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := newExpiringTokenServer(t, "never-issued", &calls)
+	defer server.Close()
+
+	provider := &rotatingTokenProvider{}
+	account := testAccount(server.URL)
+	account.TokenProvider = provider
+
+	err := newTestClient(t, server).MarkMessageRead(context.Background(), account, "wamid.1")
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 190, apiErr.Code)
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, int32(1), provider.refreshes.Load())
+}
+
+func TestClient_TokenProvider_NoRefreshWithStaticToken(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := newExpiringTokenServer(t, "fresh-token", &calls)
+	defer server.Close()
+
+	err := newTestClient(t, server).MarkMessageRead(context.Background(), testAccount(server.URL), "wamid.1")
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_TokenProvider_Error(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := newExpiringTokenServer(t, "token-1", &calls)
+	defer server.Close()
+
+	account := testAccount(server.URL)
+	account.TokenProvider = &rotatingTokenProvider{err: errors.New("vault unavailable")}
+
+	err := newTestClient(t, server).MarkMessageRead(context.Background(), account, "wamid.1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "vault unavailable")
+	assert.Zero(t, calls.Load())
+}
+
+func TestStaticToken(t *testing.T) {
+	t.Parallel()
+
+	token, expiry, err := whatsapp.StaticToken("abc").Token(context.Background(), true)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", token)
+	assert.True(t, expiry.IsZero())
+}
+
+func TestClient_ExchangeCodeForToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth/access_token", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		q := r.URL.Query()
+		assert.Equal(t, "app-1", q.Get("client_id"))
+		assert.Equal(t, "secret", q.Get("client_secret"))
+		assert.Equal(t, "auth-code", q.Get("code"))
+		assert.Equal(t, "https://app.example.com/callback", q.Get("redirect_uri"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"access_token":"business-token","token_type":"bearer","expires_in":5183944}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	token, err := client.ExchangeCodeForToken(context.Background(), "app-1", "secret", "auth-code", "https://app.example.com/callback")
+	require.NoError(t, err)
+	assert.Equal(t, "business-token", token.AccessToken)
+	assert.Equal(t, int64(5183944), token.ExpiresIn)
+}
+
+func TestClient_ExchangeCodeForToken_InvalidCode(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"This authorization code has been used.","type":"OAuthException","code":100}}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	_, err := client.ExchangeCodeForToken(context.Background(), "app-1", "secret", "used-code", "")
+	require.Error(t, err)
+
+	var apiErr *whatsapp.GraphAPIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 100, apiErr.Code)
+}
+
+func TestClient_ExchangeCodeForToken_TransportErrorHidesSecret(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	client := whatsapp.New(testutil.NopLogger(), whatsapp.WithBaseURL(server.URL))
+	_, err := client.ExchangeCodeForToken(context.Background(), "app-1", "app-secret-value", "auth-code-value", "")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "app-secret-value")
+	assert.NotContains(t, err.Error(), "auth-code-value")
+	assert.Contains(t, err.Error(), "REDACTED")
+}
+
+func TestClient_ExtendToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/oauth/access_token", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "fb_exchange_token", q.Get("grant_type"))
+		assert.Equal(t, "short-token", q.Get("fb_exchange_token"))
+		assert.Equal(t, "secret", q.Get("client_secret"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"access_token":"long-token","token_type":"bearer","expires_in":5184000}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	token, err := client.ExtendToken(context.Background(), "app-1", "secret", "short-token")
+	require.NoError(t, err)
+	assert.Equal(t, "long-token", token.AccessToken)
+	assert.Equal(t, int64(5184000), token.ExpiresIn)
+}
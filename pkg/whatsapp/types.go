@@ -9,6 +9,9 @@ type Account struct {
 	AppID       string
 	APIVersion  string
 	AccessToken string
+	// TokenProvider supplies tokens instead of AccessToken when set. Requests
+	// rejected with an expired token (code 190) are retried once with a refreshed token.
+	TokenProvider TokenProvider
 }
 
 // Button represents an interactive button
@@ -82,7 +85,8 @@ type TemplateExample struct {
 
 // TemplateListResponse represents response from fetching templates
 type TemplateListResponse struct {
-	Data []MetaTemplate `json:"data"`
+	Data   []MetaTemplate `json:"data"`
+	Paging Paging         `json:"paging"`
 }
 
 // WebhookPayload represents the incoming webhook from Meta
@@ -110,6 +114,7 @@ type WebhookValue struct {
 	Contacts         []WebhookContact `json:"contacts,omitempty"`
 	Messages         []WebhookMessage `json:"messages,omitempty"`
 	Statuses         []WebhookStatus  `json:"statuses,omitempty"`
+	Errors           []WebhookError   `json:"errors,omitempty"`
 }
 
 // WebhookMetadata represents metadata in webhook
@@ -138,7 +143,9 @@ type WebhookMessage struct {
 	Document    *WebhookMedia           `json:"document,omitempty"`
 	Audio       *WebhookMedia           `json:"audio,omitempty"`
 	Video       *WebhookMedia           `json:"video,omitempty"`
+	Button      *WebhookButton          `json:"button,omitempty"`
 	Context     *WebhookMessageContext  `json:"context,omitempty"`
+	Order       *Order                  `json:"order,omitempty"`
 }
 
 // WebhookText represents text content in a message
@@ -167,6 +174,12 @@ type WebhookListReply struct {
 	Description string `json:"description,omitempty"`
 }
 
+// WebhookButton represents a quick reply button press on a template message
+type WebhookButton struct {
+	Payload string `json:"payload"`
+	Text    string `json:"text"`
+}
+
 // WebhookNFMReply represents a flow reply
 type WebhookNFMReply struct {
 	ResponseJSON string `json:"response_json"`
@@ -183,6 +196,21 @@ type WebhookMedia struct {
 	Filename string `json:"filename,omitempty"`
 }
 
+// Order is a cart a customer sent from a catalog, product or multi-product message
+type Order struct {
+	CatalogID    string      `json:"catalog_id"`
+	Text         string      `json:"text,omitempty"` // Note the customer added to the order, if any
+	ProductItems []OrderItem `json:"product_items"`
+}
+
+// OrderItem is a product line of an order
+type OrderItem struct {
+	ProductRetailerID string  `json:"product_retailer_id"`
+	Quantity          int     `json:"quantity"`
+	ItemPrice         float64 `json:"item_price"` // Unit price as a decimal amount, e.g. 12.99
+	Currency          string  `json:"currency"`
+}
+
 // WebhookMessageContext represents message context (for replies)
 type WebhookMessageContext struct {
 	From      string `json:"from"`
@@ -192,20 +220,119 @@ type WebhookMessageContext struct {
 
 // WebhookStatus represents a message status update
 type WebhookStatus struct {
-	ID          string               `json:"id"`
-	Status      string               `json:"status"`
-	Timestamp   string               `json:"timestamp"`
-	RecipientID string               `json:"recipient_id"`
-	Errors      []WebhookStatusError `json:"errors,omitempty"`
+	ID           string               `json:"id"`
+	Status       string               `json:"status"`
+	Timestamp    string               `json:"timestamp"`
+	RecipientID  string               `json:"recipient_id"`
+	CallbackData string               `json:"biz_opaque_callback_data,omitempty"`
+	Conversation *WebhookConversation `json:"conversation,omitempty"`
+	Pricing      *WebhookPricing      `json:"pricing,omitempty"`
+	Errors       []WebhookStatusError `json:"errors,omitempty"`
+}
+
+// WebhookConversation is the conversation a status update was billed under
+type WebhookConversation struct {
+	ID                  string `json:"id"`
+	ExpirationTimestamp string `json:"expiration_timestamp,omitempty"` // Only on the first sent status
+	Origin              struct {
+		Type string `json:"type"` // marketing, utility, authentication, service, referral_conversion
+	} `json:"origin"`
+}
+
+// WebhookPricing describes how a message is billed
+type WebhookPricing struct {
+	Billable     bool   `json:"billable"`
+	PricingModel string `json:"pricing_model"` // CBP (conversation-based) or PMP (per-message)
+	Category     string `json:"category"`      // marketing, utility, authentication, service, ...
 }
 
 // WebhookStatusError represents an error in status update
 type WebhookStatusError struct {
-	Code    int    `json:"code"`
-	Title   string `json:"title"`
-	Message string `json:"message"`
+	Code      int    `json:"code"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	ErrorData struct {
+		Details string `json:"details"`
+	} `json:"error_data"`
+}
+
+// WebhookError represents an error reported at the webhook change level
+type WebhookError struct {
+	Code      int    `json:"code"`
+	Title     string `json:"title"`
+	Message   string `json:"message"`
+	ErrorData struct {
+		Details string `json:"details"`
+	} `json:"error_data"`
+}
+
+// WebhookEvent is the typed content of a webhook payload
+type WebhookEvent struct {
+	Messages []InboundMessage
+	Errors   []WebhookError
+
+	statuses []StatusUpdate
+}
+
+// DeliveryStatus is the state reported in a message status update
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent      DeliveryStatus = "sent"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusRead      DeliveryStatus = "read"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+	DeliveryStatusDeleted   DeliveryStatus = "deleted"
+)
+
+// StatusUpdate is a delivery status with its conversation and pricing details
+type StatusUpdate struct {
+	MessageID     string
+	RecipientID   string
+	PhoneNumberID string
+	Status        DeliveryStatus
+	Timestamp     time.Time
+	CallbackData  string // Set with WithCallbackData when the message was sent
+
+	ConversationID        string
+	ConversationOrigin    string    // Origin type, e.g. marketing or service
+	ConversationExpiresAt time.Time // Zero unless reported, i.e. on the first sent status
+
+	Billable        bool
+	PricingModel    string
+	PricingCategory string
+
+	Errors []WebhookStatusError
 }
 
+// InboundMessage is an incoming message with the context of the change it arrived in
+// Its Text and Type methods shadow the embedded fields of the same name; the raw
+// values remain available as WebhookMessage.Text and WebhookMessage.Type.
+type InboundMessage struct {
+	WebhookMessage
+	PhoneNumberID string
+	ContactName   string
+	ReceivedAt    time.Time
+}
+
+// MessageType identifies the kind of an inbound message. Interactive replies
+// are reported by their reply type rather than as "interactive".
+type MessageType string
+
+const (
+	MessageTypeText        MessageType = "text"
+	MessageTypeImage       MessageType = "image"
+	MessageTypeDocument    MessageType = "document"
+	MessageTypeAudio       MessageType = "audio"
+	MessageTypeVideo       MessageType = "video"
+	MessageTypeButton      MessageType = "button" // Quick reply button on a template
+	MessageTypeButtonReply MessageType = "button_reply"
+	MessageTypeListReply   MessageType = "list_reply"
+	MessageTypeFlowReply   MessageType = "nfm_reply"
+	MessageTypeInteractive MessageType = "interactive" // Other interactive replies
+	MessageTypeOrder       MessageType = "order"       // Cart sent from the catalog; see WebhookMessage.Order
+)
+
 // ParsedMessage represents a parsed incoming message
 type ParsedMessage struct {
 	From          string
@@ -233,43 +360,219 @@ type ParsedStatus struct {
 	ErrorMsg    string
 }
 
+// Contact represents a contact card sent in a contacts message
+type Contact struct {
+	Name      ContactName      `json:"name"`
+	Phones    []ContactPhone   `json:"phones,omitempty"`
+	Emails    []ContactEmail   `json:"emails,omitempty"`
+	Org       *ContactOrg      `json:"org,omitempty"`
+	Addresses []ContactAddress `json:"addresses,omitempty"`
+	URLs      []ContactURL     `json:"urls,omitempty"`
+	Birthday  string           `json:"birthday,omitempty"` // YYYY-MM-DD
+}
+
+// ContactName represents the name of a contact. FormattedName is required.
+type ContactName struct {
+	FormattedName string `json:"formatted_name"`
+	FirstName     string `json:"first_name,omitempty"`
+	LastName      string `json:"last_name,omitempty"`
+	MiddleName    string `json:"middle_name,omitempty"`
+	Prefix        string `json:"prefix,omitempty"`
+	Suffix        string `json:"suffix,omitempty"`
+}
+
+// ContactPhone represents a phone number of a contact
+type ContactPhone struct {
+	Phone string `json:"phone"`
+	Type  string `json:"type,omitempty"`  // e.g. CELL, MAIN, HOME, WORK
+	WaID  string `json:"wa_id,omitempty"` // Adds a "Message" button for this WhatsApp ID
+}
+
+// ContactEmail represents an email address of a contact
+type ContactEmail struct {
+	Email string `json:"email"`
+	Type  string `json:"type,omitempty"` // HOME or WORK
+}
+
+// ContactOrg represents the organization of a contact
+type ContactOrg struct {
+	Company    string `json:"company,omitempty"`
+	Department string `json:"department,omitempty"`
+	Title      string `json:"title,omitempty"`
+}
+
+// ContactAddress represents a postal address of a contact
+type ContactAddress struct {
+	Street      string `json:"street,omitempty"`
+	City        string `json:"city,omitempty"`
+	State       string `json:"state,omitempty"`
+	Zip         string `json:"zip,omitempty"`
+	Country     string `json:"country,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	Type        string `json:"type,omitempty"` // HOME or WORK
+}
+
+// ContactURL represents a website of a contact
+type ContactURL struct {
+	URL  string `json:"url"`
+	Type string `json:"type,omitempty"` // HOME or WORK
+}
+
 // CatalogInfo represents a catalog from Meta API
 type CatalogInfo struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ProductCount int    `json:"product_count"`
+	Vertical     string `json:"vertical,omitempty"` // e.g. "commerce"; omitted by Meta for some catalogs
 }
 
 // CatalogListResponse represents response from listing catalogs
 type CatalogListResponse struct {
-	Data []CatalogInfo `json:"data"`
+	Data   []CatalogInfo `json:"data"`
+	Paging Paging        `json:"paging"`
 }
 
 // ProductInput represents input for creating/updating a product
 type ProductInput struct {
-	Name        string `json:"name"`
-	Price       int64  `json:"price"`    // Price in cents
-	Currency    string `json:"currency"`
-	URL         string `json:"url"`
-	ImageURL    string `json:"image_url"`
-	RetailerID  string `json:"retailer_id"` // SKU
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Price       int64    `json:"price"` // Price in the currency's minor units (e.g. cents)
+	Currency    Currency `json:"currency"`
+	URL         string   `json:"url"`
+	ImageURL    string   `json:"image_url"`
+	RetailerID  string   `json:"retailer_id"` // SKU
+	Description string   `json:"description"`
+	// ImageHandle is an UploadCatalogImage handle, used when ImageURL is empty
+	ImageHandle string `json:"image_handle,omitempty"`
+	// AdditionalImageURLs are up to 20 more images shown after the main image
+	AdditionalImageURLs []string `json:"additional_image_urls,omitempty"`
+	// Variants holds variant attributes such as size or color
+	Variants []VariantAttribute `json:"variants,omitempty"`
+	// RetailerProductGroupID is the retailer ID of a product group (see
+	// CreateProductGroup); variants sharing it are shown as one listing
+	RetailerProductGroupID string `json:"retailer_product_group_id,omitempty"`
+	// Availability is one of the ProductAvailability* values; empty leaves Meta's default
+	Availability Availability `json:"availability,omitempty"`
+	// Condition is one of the ProductCondition* values; empty leaves Meta's default
+	Condition string `json:"condition,omitempty"`
+	// CustomLabels are sent as custom_label_0 to custom_label_4, e.g. for ad
+	// targeting; empty labels are not sent
+	CustomLabels [5]string `json:"custom_labels"`
+	// CustomData holds arbitrary key-value pairs sent as the custom_data object
+	CustomData map[string]string `json:"custom_data,omitempty"`
+	// SalePrice is a discounted price in minor units, shown with the regular
+	// price struck through; 0 means no sale
+	SalePrice int64 `json:"sale_price,omitempty"`
+	// SalePriceStart and SalePriceEnd optionally limit the sale to a period;
+	// set both or neither
+	SalePriceStart time.Time `json:"sale_price_start"`
+	SalePriceEnd   time.Time `json:"sale_price_end"`
+}
+
+// VariantAttribute represents a single variant attribute of a product
+type VariantAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // ProductInfo represents a product from Meta API
 type ProductInfo struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Price       string `json:"price"`
-	Currency    string `json:"currency"`
-	URL         string `json:"url"`
-	ImageURL    string `json:"image_url"`
-	RetailerID  string `json:"retailer_id"`
-	Description string `json:"description"`
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Price        string `json:"price"`
+	Currency     string `json:"currency"`
+	URL          string `json:"url"`
+	ImageURL     string `json:"image_url"`
+	RetailerID   string `json:"retailer_id"`
+	Description  string `json:"description"`
+	Availability string `json:"availability"`
+	Condition    string `json:"condition"`
+	// RetailerProductGroupID is set for products that are variants in a product group
+	RetailerProductGroupID string   `json:"retailer_product_group_id,omitempty"`
+	AdditionalImageURLs    []string `json:"additional_image_urls,omitempty"`
 }
 
 // ProductListResponse represents response from listing products
 type ProductListResponse struct {
-	Data []ProductInfo `json:"data"`
+	Data   []ProductInfo `json:"data"`
+	Paging Paging        `json:"paging"`
+}
+
+// Paging represents cursor-based pagination info in Meta API list responses
+type Paging struct {
+	Cursors struct {
+		Before string `json:"before,omitempty"`
+		After  string `json:"after,omitempty"`
+	} `json:"cursors"`
+	Next string `json:"next,omitempty"`
+}
+
+// NextCursor returns the cursor for the next page, or empty on the last page.
+// Meta keeps returning cursors.after on the final page, so only trust it when
+// a next link is present.
+func (p Paging) NextCursor() string {
+	if p.Next == "" {
+		return ""
+	}
+	return p.Cursors.After
+}
+
+// Page is a single page of a paginated list
+type Page[T any] struct {
+	Data []T
+	// NextCursor is passed to the next call to fetch the following page;
+	// empty on the last page
+	NextCursor string
+	// TotalCount is the total number of items across all pages, or -1 when
+	// Meta does not report it for the edge
+	TotalCount int
+}
+
+// HasMore reports whether there are pages after this one
+func (p *Page[T]) HasMore() bool {
+	return p.NextCursor != ""
+}
+
+// BatchResult represents the outcome of a single item in a catalog batch request
+type BatchResult struct {
+	RetailerID string
+	Handle     string // Batch handle for checking asynchronous processing status
+	FBTraceID  string // Meta trace ID of the batch request, for support tickets
+	Err        error  // Set if the item was rejected or its batch request failed
+}
+
+// ProductSetFilter selects the products in a product set.
+// When both conditions are set a product must match both.
+type ProductSetFilter struct {
+	RetailerIDs []string // Products whose retailer ID is any of these
+	ProductType string   // Products whose product type contains this value (case-insensitive)
+}
+
+// ProductSearchOptions narrows SearchProducts. Set conditions are combined
+// with "and"; the zero value matches every product.
+type ProductSearchOptions struct {
+	RetailerIDs  []string     // Products whose retailer ID is any of these
+	Availability Availability // One of the ProductAvailability* values
+	MinPrice     int64        // Lower price bound in minor units (e.g. cents); 0 means unbounded
+	MaxPrice     int64        // Upper price bound in minor units; 0 means unbounded
+	// Filter is an additional raw Graph API filter object, e.g.
+	// {"brand":{"i_contains":"acme"}}, for conditions not covered above
+	Filter string
+	// Fields selects the product fields to return; empty uses the default set
+	Fields []string
+}
+
+// ProductSet represents a product set (collection) within a catalog
+type ProductSet struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Filter       string `json:"filter"` // JSON-encoded filter as stored by Meta
+	ProductCount int    `json:"product_count"`
+}
+
+// ProductSetListResponse represents response from listing product sets
+type ProductSetListResponse struct {
+	Data   []ProductSet `json:"data"`
+	Paging Paging       `json:"paging"`
 }
 
 // ProductCreateResponse represents response from creating a product
@@ -277,6 +580,31 @@ type ProductCreateResponse struct {
 	ID string `json:"id"`
 }
 
+// PhoneNumber represents a phone number registered to a WhatsApp Business Account
+type PhoneNumber struct {
+	ID                     string `json:"id"` // Phone number ID used for messaging calls
+	DisplayPhoneNumber     string `json:"display_phone_number"`
+	VerifiedName           string `json:"verified_name"`
+	QualityRating          string `json:"quality_rating"`       // GREEN, YELLOW, RED or UNKNOWN
+	MessagingLimitTier     string `json:"messaging_limit_tier"` // e.g. TIER_1K, TIER_10K, TIER_UNLIMITED
+	CodeVerificationStatus string `json:"code_verification_status"`
+	NameStatus             string `json:"name_status"`     // Review status of the display name, e.g. APPROVED, PENDING_REVIEW, DECLINED
+	NewNameStatus          string `json:"new_name_status"` // Review status of a requested display name change
+}
+
+// PhoneNumberListResponse represents response from listing phone numbers
+type PhoneNumberListResponse struct {
+	Data   []PhoneNumber `json:"data"`
+	Paging Paging        `json:"paging"`
+}
+
+// CommerceSettings controls the shopping features of a phone number
+type CommerceSettings struct {
+	ID               string `json:"id"`
+	IsCartEnabled    bool   `json:"is_cart_enabled"`
+	IsCatalogVisible bool   `json:"is_catalog_visible"` // Shows the catalog button in the chat and business profile
+}
+
 // BusinessProfile represents the business profile of a phone number
 type BusinessProfile struct {
 	MessagingProduct string   `json:"messaging_product"`
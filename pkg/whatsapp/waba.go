@@ -0,0 +1,102 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// WABA is a WhatsApp Business Account linked to a Meta business
+type WABA struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	TimezoneID string `json:"timezone_id"` // Meta timezone ID, e.g. "1" for America/Los_Angeles
+}
+
+// wabaFields are the WABA fields requested when listing a business's WABAs
+const wabaFields = "id,name,timezone_id"
+
+// ListOwnedWABAs lists the WhatsApp Business Accounts owned by a Meta
+// business, following pagination. businessID is the Business Manager ID,
+// not a WABA ID.
+func (c *Client) ListOwnedWABAs(ctx context.Context, account *Account, businessID string) ([]WABA, error) {
+	return c.listWABAs(ctx, account, businessID, "owned_whatsapp_business_accounts")
+}
+
+// ListClientWABAs lists the WhatsApp Business Accounts that clients have
+// shared with a Meta business, such as a tech provider, following pagination
+func (c *Client) ListClientWABAs(ctx context.Context, account *Account, businessID string) ([]WABA, error) {
+	return c.listWABAs(ctx, account, businessID, "client_whatsapp_business_accounts")
+}
+
+// listWABAs lists all WABAs on a business edge
+func (c *Client) listWABAs(ctx context.Context, account *Account, businessID, edge string) ([]WABA, error) {
+	if businessID == "" {
+		return nil, fmt.Errorf("business ID is required")
+	}
+
+	params := url.Values{}
+	params.Add("fields", wabaFields)
+	apiURL := fmt.Sprintf("%s/%s/%s/%s", c.getBaseURL(), account.APIVersion, businessID, edge)
+	wabas, err := listAll[WABA](ctx, c, account, apiURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WhatsApp Business Accounts: %w", err)
+	}
+	return wabas, nil
+}
+
+// Messaging availability reported by GetHealthStatus
+const (
+	HealthAvailable = "AVAILABLE"
+	HealthLimited   = "LIMITED"
+	HealthBlocked   = "BLOCKED"
+)
+
+// HealthStatus is the messaging health of a phone number, WABA or app, with
+// the status of each entity involved in sending
+type HealthStatus struct {
+	CanSendMessage string         `json:"can_send_message"` // One of the Health* values
+	Entities       []HealthEntity `json:"entities"`
+}
+
+// HealthEntity is the health of one entity, e.g. the phone number, its WABA
+// or the Meta business
+type HealthEntity struct {
+	EntityType     string        `json:"entity_type"` // e.g. PHONE_NUMBER, WABA, BUSINESS, APP
+	ID             string        `json:"id"`
+	CanSendMessage string        `json:"can_send_message"`
+	Errors         []HealthError `json:"errors"`
+	AdditionalInfo []string      `json:"additional_info"`
+}
+
+// HealthError explains why an entity is limited or blocked
+type HealthError struct {
+	ErrorCode        int    `json:"error_code"`
+	ErrorDescription string `json:"error_description"`
+	PossibleSolution string `json:"possible_solution"`
+}
+
+// GetHealthStatus returns the messaging health of a phone number or WABA.
+// nodeID is the phone number ID or WABA ID to check.
+func (c *Client) GetHealthStatus(ctx context.Context, account *Account, nodeID string) (*HealthStatus, error) {
+	if nodeID == "" {
+		return nil, fmt.Errorf("node ID is required")
+	}
+
+	apiURL := fmt.Sprintf("%s/%s/%s?fields=health_status", c.getBaseURL(), account.APIVersion, nodeID)
+	respBody, err := c.doRequest(ctx, http.MethodGet, apiURL, nil, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get health status: %w", err)
+	}
+
+	var resp struct {
+		HealthStatus HealthStatus `json:"health_status"`
+	}
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.HealthStatus, nil
+}
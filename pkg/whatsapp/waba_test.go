@@ -0,0 +1,109 @@
+package whatsapp_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_ListOwnedWABAs(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/biz-1/owned_whatsapp_business_accounts", r.URL.Path)
+		assert.Equal(t, "id,name,timezone_id", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("after") == "" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []map[string]string{{"id": "waba-1", "name": "Acme", "timezone_id": "1"}},
+				"paging": map[string]interface{}{
+					"cursors": map[string]string{"after": "next"},
+					"next":    "https://graph.facebook.com/next",
+				},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []map[string]string{{"id": "waba-2", "name": "Acme EU", "timezone_id": "71"}},
+		})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	wabas, err := client.ListOwnedWABAs(context.Background(), testAccount(server.URL), "biz-1")
+	require.NoError(t, err)
+	require.Len(t, wabas, 2)
+	assert.Equal(t, "waba-1", wabas[0].ID)
+	assert.Equal(t, "Acme", wabas[0].Name)
+	assert.Equal(t, "71", wabas[1].TimezoneID)
+}
+
+func TestClient_ListClientWABAs(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v21.0/biz-1/client_whatsapp_business_accounts", r.URL.Path)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"data":[{"id":"waba-9","name":"Client Co","timezone_id":"5"}]}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	wabas, err := client.ListClientWABAs(context.Background(), testAccount(server.URL), "biz-1")
+	require.NoError(t, err)
+	require.Len(t, wabas, 1)
+	assert.Equal(t, "Client Co", wabas[0].Name)
+}
+
+func TestClient_GetHealthStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v21.0/123456789", r.URL.Path)
+		assert.Equal(t, "health_status", r.URL.Query().Get("fields"))
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{
+			"health_status": {
+				"can_send_message": "BLOCKED",
+				"entities": [
+					{"entity_type": "PHONE_NUMBER", "id": "123456789", "can_send_message": "AVAILABLE"},
+					{
+						"entity_type": "WABA",
+						"id": "987654321",
+						"can_send_message": "BLOCKED",
+						"errors": [{
+							"error_code": 141010,
+							"error_description": "The Business has not passed business verification.",
+							"possible_solution": "Visit business settings and start or resolve the business verification request."
+						}]
+					}
+				]
+			},
+			"id": "123456789"
+		}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	status, err := client.GetHealthStatus(context.Background(), testAccount(server.URL), "123456789")
+	require.NoError(t, err)
+	assert.Equal(t, whatsapp.HealthBlocked, status.CanSendMessage)
+	require.Len(t, status.Entities, 2)
+	assert.Equal(t, "PHONE_NUMBER", status.Entities[0].EntityType)
+	assert.Empty(t, status.Entities[0].Errors)
+	assert.Equal(t, "WABA", status.Entities[1].EntityType)
+	require.Len(t, status.Entities[1].Errors, 1)
+	assert.Equal(t, 141010, status.Entities[1].Errors[0].ErrorCode)
+	assert.Contains(t, status.Entities[1].Errors[0].PossibleSolution, "business verification")
+}
@@ -1,9 +1,17 @@
 package whatsapp
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,12 +20,57 @@ func VerifyWebhook(mode, token, challenge, expectedToken string) (string, error)
 	if mode != "subscribe" {
 		return "", fmt.Errorf("invalid mode: %s", mode)
 	}
-	if token != expectedToken {
+	if expectedToken == "" {
+		return "", fmt.Errorf("verify token not configured")
+	}
+	// Constant-time comparison so the token can't be guessed through response timing
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expectedToken)) != 1 {
 		return "", fmt.Errorf("token mismatch")
 	}
 	return challenge, nil
 }
 
+// VerifyWebhookQuery verifies the hub.mode, hub.verify_token and hub.challenge
+// query parameters of Meta's subscription request and returns the challenge to echo back
+func VerifyWebhookQuery(values url.Values, expectedToken string) (string, error) {
+	return VerifyWebhook(values.Get("hub.mode"), values.Get("hub.verify_token"), values.Get("hub.challenge"), expectedToken)
+}
+
+// signaturePrefix is the prefix of the X-Hub-Signature-256 header value
+const signaturePrefix = "sha256="
+
+var (
+	// ErrInvalidSignatureHeader is returned when the X-Hub-Signature-256 header is missing or malformed
+	ErrInvalidSignatureHeader = errors.New("invalid webhook signature header")
+	// ErrSignatureMismatch is returned when the payload signature does not match the app secret
+	ErrSignatureMismatch = errors.New("webhook signature mismatch")
+)
+
+// VerifyWebhookSignature checks the X-Hub-Signature-256 header against the
+// HMAC-SHA256 of the payload computed with the app secret.
+// payload must be the raw request body exactly as received; re-serializing
+// parsed JSON changes the bytes and the signature will not match.
+func VerifyWebhookSignature(payload []byte, signatureHeader, appSecret string) error {
+	if appSecret == "" {
+		return fmt.Errorf("app secret not configured")
+	}
+	if !strings.HasPrefix(signatureHeader, signaturePrefix) {
+		return ErrInvalidSignatureHeader
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(signatureHeader, signaturePrefix))
+	if err != nil || len(signature) != sha256.Size {
+		return ErrInvalidSignatureHeader
+	}
+
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
 // ParseWebhook parses the incoming webhook payload from Meta
 func ParseWebhook(body []byte) (*WebhookPayload, error) {
 	var payload WebhookPayload
@@ -27,6 +80,171 @@ func ParseWebhook(body []byte) (*WebhookPayload, error) {
 	return &payload, nil
 }
 
+// ParseWebhookEvent parses the incoming webhook payload into inbound messages,
+// delivery statuses and errors across all entries and changes
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	payload, err := ParseWebhook(body)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &WebhookEvent{}
+	for _, entry := range payload.Entry {
+		for _, change := range entry.Changes {
+			event.Errors = append(event.Errors, change.Value.Errors...)
+			if change.Field != "messages" {
+				continue
+			}
+
+			for _, status := range change.Value.Statuses {
+				event.statuses = append(event.statuses, newStatusUpdate(status, change.Value.Metadata.PhoneNumberID))
+			}
+			for _, msg := range change.Value.Messages {
+				event.Messages = append(event.Messages, InboundMessage{
+					WebhookMessage: msg,
+					PhoneNumberID:  change.Value.Metadata.PhoneNumberID,
+					ContactName:    contactName(change.Value.Contacts, msg.From),
+					ReceivedAt:     parseTimestamp(msg.Timestamp),
+				})
+			}
+		}
+	}
+
+	return event, nil
+}
+
+// Statuses returns the event's delivery status updates with conversation and pricing details
+func (e *WebhookEvent) Statuses() []StatusUpdate {
+	return e.statuses
+}
+
+// newStatusUpdate flattens a webhook status into a StatusUpdate
+func newStatusUpdate(status WebhookStatus, phoneNumberID string) StatusUpdate {
+	update := StatusUpdate{
+		MessageID:     status.ID,
+		RecipientID:   status.RecipientID,
+		PhoneNumberID: phoneNumberID,
+		Status:        DeliveryStatus(status.Status),
+		Timestamp:     parseTimestamp(status.Timestamp),
+		CallbackData:  status.CallbackData,
+		Errors:        status.Errors,
+	}
+	if status.Conversation != nil {
+		update.ConversationID = status.Conversation.ID
+		update.ConversationOrigin = status.Conversation.Origin.Type
+		if status.Conversation.ExpirationTimestamp != "" {
+			update.ConversationExpiresAt = parseTimestamp(status.Conversation.ExpirationTimestamp)
+		}
+	}
+	if status.Pricing != nil {
+		update.Billable = status.Pricing.Billable
+		update.PricingModel = status.Pricing.PricingModel
+		update.PricingCategory = status.Pricing.Category
+	}
+	return update
+}
+
+// UnitPrice returns the item's unit price in the currency's minor units
+// (see FormatPrice), e.g. 12.99 USD -> 1299
+func (i OrderItem) UnitPrice() int64 {
+	return int64(math.Round(i.ItemPrice * math.Pow10(CurrencyExponent(i.Currency))))
+}
+
+// Total returns the order total in minor units and its currency. All items of
+// an order share the catalog's currency; an error is returned if they do not.
+func (o *Order) Total() (int64, string, error) {
+	var total int64
+	currency := ""
+	for _, item := range o.ProductItems {
+		if currency == "" {
+			currency = item.Currency
+		} else if !strings.EqualFold(item.Currency, currency) {
+			return 0, "", fmt.Errorf("order mixes currencies %s and %s", currency, item.Currency)
+		}
+		total += item.UnitPrice() * int64(item.Quantity)
+	}
+	return total, currency, nil
+}
+
+// Type returns the message type. Other types, such as "location" or
+// "reaction", are returned as Meta reports them.
+func (m *InboundMessage) Type() MessageType {
+	if m.WebhookMessage.Type == "interactive" && m.Interactive != nil {
+		switch m.Interactive.Type {
+		case "button_reply", "list_reply", "nfm_reply":
+			return MessageType(m.Interactive.Type)
+		}
+	}
+	return MessageType(m.WebhookMessage.Type)
+}
+
+// Text returns the text the customer sent: the body of a text message, the
+// title of a selected button or list row, or the body of a flow reply.
+// ok is false for messages without text, such as media.
+func (m *InboundMessage) Text() (string, bool) {
+	switch m.Type() {
+	case MessageTypeText:
+		if m.WebhookMessage.Text != nil {
+			return m.WebhookMessage.Text.Body, true
+		}
+	case MessageTypeButton:
+		if m.Button != nil {
+			return m.Button.Text, true
+		}
+	case MessageTypeButtonReply, MessageTypeListReply:
+		if _, title, ok := m.Reply(); ok {
+			return title, true
+		}
+	case MessageTypeFlowReply:
+		if m.Interactive.NFMReply != nil {
+			return m.Interactive.NFMReply.Body, true
+		}
+	}
+	return "", false
+}
+
+// Reply returns the ID and title of the selected button or list row.
+// For template quick reply buttons the ID is the button payload.
+func (m *InboundMessage) Reply() (id, title string, ok bool) {
+	switch m.Type() {
+	case MessageTypeButton:
+		if m.Button != nil {
+			return m.Button.Payload, m.Button.Text, true
+		}
+	case MessageTypeButtonReply:
+		if reply := m.Interactive.ButtonReply; reply != nil {
+			return reply.ID, reply.Title, true
+		}
+	case MessageTypeListReply:
+		if reply := m.Interactive.ListReply; reply != nil {
+			return reply.ID, reply.Title, true
+		}
+	}
+	return "", "", false
+}
+
+// contactName returns the profile name of the sender, falling back to the
+// first contact when no wa_id matches
+func contactName(contacts []WebhookContact, from string) string {
+	for _, c := range contacts {
+		if c.WaID == from {
+			return c.Profile.Name
+		}
+	}
+	if len(contacts) > 0 {
+		return contacts[0].Profile.Name
+	}
+	return ""
+}
+
+// parseTimestamp parses a Unix timestamp string, returning the zero time if invalid
+func parseTimestamp(ts string) time.Time {
+	if sec, err := strconv.ParseInt(ts, 10, 64); err == nil {
+		return time.Unix(sec, 0)
+	}
+	return time.Time{}
+}
+
 // ExtractMessages extracts all messages from a webhook payload
 func (p *WebhookPayload) ExtractMessages() []ParsedMessage {
 	var messages []ParsedMessage
@@ -39,24 +257,14 @@ func (p *WebhookPayload) ExtractMessages() []ParsedMessage {
 
 			phoneNumberID := change.Value.Metadata.PhoneNumberID
 
-			// Get contact name
-			contactName := ""
-			if len(change.Value.Contacts) > 0 {
-				contactName = change.Value.Contacts[0].Profile.Name
-			}
-
 			for _, msg := range change.Value.Messages {
 				parsed := ParsedMessage{
 					From:          msg.From,
 					ID:            msg.ID,
 					Type:          msg.Type,
 					PhoneNumberID: phoneNumberID,
-					ContactName:   contactName,
-				}
-
-				// Parse timestamp
-				if ts, err := strconv.ParseInt(msg.Timestamp, 10, 64); err == nil {
-					parsed.Timestamp = time.Unix(ts, 0)
+					ContactName:   contactName(change.Value.Contacts, msg.From),
+					Timestamp:     parseTimestamp(msg.Timestamp),
 				}
 
 				// Extract text content based on message type
@@ -84,6 +292,11 @@ func (p *WebhookPayload) ExtractMessages() []ParsedMessage {
 							}
 						}
 					}
+				case "button":
+					if msg.Button != nil {
+						parsed.ButtonReplyID = msg.Button.Payload
+						parsed.Text = msg.Button.Text
+					}
 				case "image":
 					if msg.Image != nil {
 						parsed.MediaID = msg.Image.ID
@@ -128,11 +341,7 @@ func (p *WebhookPayload) ExtractStatuses() []ParsedStatus {
 					MessageID:   status.ID,
 					Status:      status.Status,
 					RecipientID: status.RecipientID,
-				}
-
-				// Parse timestamp
-				if ts, err := strconv.ParseInt(status.Timestamp, 10, 64); err == nil {
-					parsed.Timestamp = time.Unix(ts, 0)
+					Timestamp:   parseTimestamp(status.Timestamp),
 				}
 
 				// Extract error info if present
@@ -1,7 +1,13 @@
 package whatsapp_test
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shridarpatil/whatomate/pkg/whatsapp"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +43,79 @@ func TestVerifyWebhook_EmptyMode(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestVerifyWebhook_EmptyExpectedToken(t *testing.T) {
+	t.Parallel()
+	_, err := whatsapp.VerifyWebhook("subscribe", "", "challenge-123", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not configured")
+}
+
+func TestVerifyWebhookQuery(t *testing.T) {
+	t.Parallel()
+	values := url.Values{}
+	values.Set("hub.mode", "subscribe")
+	values.Set("hub.verify_token", "my-token")
+	values.Set("hub.challenge", "challenge-123")
+
+	challenge, err := whatsapp.VerifyWebhookQuery(values, "my-token")
+	require.NoError(t, err)
+	assert.Equal(t, "challenge-123", challenge)
+
+	values.Set("hub.verify_token", "my-token-extra")
+	_, err = whatsapp.VerifyWebhookQuery(values, "my-token")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "token mismatch")
+}
+
+// --- VerifyWebhookSignature ---
+
+func signPayload(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	t.Parallel()
+
+	payload := []byte(`{"object":"whatsapp_business_account","entry":[]}`)
+	valid := signPayload(payload, "app-secret")
+
+	tests := []struct {
+		name      string
+		payload   []byte
+		header    string
+		secret    string
+		wantErrIs error
+		wantErr   bool
+	}{
+		{name: "valid signature", payload: payload, header: valid, secret: "app-secret"},
+		{name: "uppercase hex", payload: payload, header: "sha256=" + strings.ToUpper(strings.TrimPrefix(valid, "sha256=")), secret: "app-secret"},
+		{name: "wrong secret", payload: payload, header: valid, secret: "other-secret", wantErrIs: whatsapp.ErrSignatureMismatch},
+		{name: "modified payload", payload: append([]byte(" "), payload...), header: valid, secret: "app-secret", wantErrIs: whatsapp.ErrSignatureMismatch},
+		{name: "missing header", payload: payload, header: "", secret: "app-secret", wantErrIs: whatsapp.ErrInvalidSignatureHeader},
+		{name: "missing prefix", payload: payload, header: strings.TrimPrefix(valid, "sha256="), secret: "app-secret", wantErrIs: whatsapp.ErrInvalidSignatureHeader},
+		{name: "not hex", payload: payload, header: "sha256=zzzz", secret: "app-secret", wantErrIs: whatsapp.ErrInvalidSignatureHeader},
+		{name: "truncated", payload: payload, header: valid[:20], secret: "app-secret", wantErrIs: whatsapp.ErrInvalidSignatureHeader},
+		{name: "no app secret", payload: payload, header: valid, secret: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := whatsapp.VerifyWebhookSignature(tt.payload, tt.header, tt.secret)
+			switch {
+			case tt.wantErrIs != nil:
+				assert.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantErr:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 // --- ParseWebhook ---
 
 func TestParseWebhook_ValidPayload(t *testing.T) {
@@ -87,6 +166,299 @@ func TestParseWebhook_EmptyBody(t *testing.T) {
 	assert.Empty(t, payload.Entry)
 }
 
+// --- ParseWebhookEvent ---
+
+func TestParseWebhookEvent_MessagesStatusesAndErrors(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{
+			"id": "123",
+			"changes": [{
+				"field": "messages",
+				"value": {
+					"messaging_product": "whatsapp",
+					"metadata": {"phone_number_id": "phone-123"},
+					"contacts": [
+						{"profile": {"name": "Alice"}, "wa_id": "111"},
+						{"profile": {"name": "Bob"}, "wa_id": "222"}
+					],
+					"messages": [
+						{"from": "111", "id": "wamid.1", "timestamp": "1700000000", "type": "text", "text": {"body": "Hi"}},
+						{"from": "222", "id": "wamid.2", "timestamp": "1700000001", "type": "button", "button": {"payload": "STOP", "text": "Stop promotions"}},
+						{"from": "222", "id": "wamid.3", "timestamp": "1700000002", "type": "interactive",
+							"interactive": {"type": "button_reply", "button_reply": {"id": "yes", "title": "Yes"}}}
+					],
+					"statuses": [
+						{"id": "wamid.out", "status": "failed", "timestamp": "1700000003", "recipient_id": "333",
+							"errors": [{"code": 131026, "title": "Message undeliverable", "message": "Message undeliverable"}]}
+					],
+					"errors": [
+						{"code": 131000, "title": "Something went wrong", "message": "Something went wrong",
+							"error_data": {"details": "Unknown error"}}
+					]
+				}
+			}]
+		}]
+	}`)
+
+	event, err := whatsapp.ParseWebhookEvent(body)
+	require.NoError(t, err)
+
+	require.Len(t, event.Messages, 3)
+	assert.Equal(t, "Alice", event.Messages[0].ContactName)
+	assert.Equal(t, "phone-123", event.Messages[0].PhoneNumberID)
+	assert.Equal(t, "Hi", event.Messages[0].WebhookMessage.Text.Body)
+	assert.Equal(t, int64(1700000000), event.Messages[0].ReceivedAt.Unix())
+
+	assert.Equal(t, "Bob", event.Messages[1].ContactName)
+	require.NotNil(t, event.Messages[1].Button)
+	assert.Equal(t, "STOP", event.Messages[1].Button.Payload)
+
+	require.NotNil(t, event.Messages[2].Interactive)
+	assert.Equal(t, "yes", event.Messages[2].Interactive.ButtonReply.ID)
+
+	require.Len(t, event.Statuses(), 1)
+	assert.Equal(t, whatsapp.DeliveryStatusFailed, event.Statuses()[0].Status)
+	require.Len(t, event.Statuses()[0].Errors, 1)
+	assert.Equal(t, 131026, event.Statuses()[0].Errors[0].Code)
+
+	require.Len(t, event.Errors, 1)
+	assert.Equal(t, 131000, event.Errors[0].Code)
+	assert.Equal(t, "Unknown error", event.Errors[0].ErrorData.Details)
+}
+
+func TestInboundMessage_TextTypeAndReply(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		msg       whatsapp.WebhookMessage
+		wantType  whatsapp.MessageType
+		wantText  string
+		wantOK    bool
+		wantReply string
+	}{
+		{
+			name:     "text",
+			msg:      whatsapp.WebhookMessage{Type: "text", Text: &whatsapp.WebhookText{Body: "Hi"}},
+			wantType: whatsapp.MessageTypeText, wantText: "Hi", wantOK: true,
+		},
+		{
+			name:     "template button",
+			msg:      whatsapp.WebhookMessage{Type: "button", Button: &whatsapp.WebhookButton{Payload: "STOP", Text: "Stop"}},
+			wantType: whatsapp.MessageTypeButton, wantText: "Stop", wantOK: true, wantReply: "STOP",
+		},
+		{
+			name: "button reply",
+			msg: whatsapp.WebhookMessage{Type: "interactive", Interactive: &whatsapp.WebhookInteractive{
+				Type: "button_reply", ButtonReply: &whatsapp.WebhookButtonReply{ID: "yes", Title: "Yes"},
+			}},
+			wantType: whatsapp.MessageTypeButtonReply, wantText: "Yes", wantOK: true, wantReply: "yes",
+		},
+		{
+			name: "list reply",
+			msg: whatsapp.WebhookMessage{Type: "interactive", Interactive: &whatsapp.WebhookInteractive{
+				Type: "list_reply", ListReply: &whatsapp.WebhookListReply{ID: "row-2", Title: "Large"},
+			}},
+			wantType: whatsapp.MessageTypeListReply, wantText: "Large", wantOK: true, wantReply: "row-2",
+		},
+		{
+			name: "flow reply",
+			msg: whatsapp.WebhookMessage{Type: "interactive", Interactive: &whatsapp.WebhookInteractive{
+				Type: "nfm_reply", NFMReply: &whatsapp.WebhookNFMReply{Body: "Sent", ResponseJSON: `{"flow_token":"t"}`},
+			}},
+			wantType: whatsapp.MessageTypeFlowReply, wantText: "Sent", wantOK: true,
+		},
+		{
+			name:     "image",
+			msg:      whatsapp.WebhookMessage{Type: "image", Image: &whatsapp.WebhookMedia{ID: "media-1", Caption: "Look"}},
+			wantType: whatsapp.MessageTypeImage,
+		},
+		{
+			name:     "unmodeled type",
+			msg:      whatsapp.WebhookMessage{Type: "location"},
+			wantType: whatsapp.MessageType("location"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := whatsapp.InboundMessage{WebhookMessage: tt.msg}
+			assert.Equal(t, tt.wantType, msg.Type())
+
+			text, ok := msg.Text()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantText, text)
+
+			id, _, ok := msg.Reply()
+			assert.Equal(t, tt.wantReply != "", ok)
+			assert.Equal(t, tt.wantReply, id)
+		})
+	}
+}
+
+func TestWebhookEvent_Statuses(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{
+			"id": "123",
+			"changes": [{
+				"field": "messages",
+				"value": {
+					"messaging_product": "whatsapp",
+					"metadata": {"phone_number_id": "phone-123"},
+					"statuses": [
+						{"id": "wamid.out", "status": "sent", "timestamp": "1700000000", "recipient_id": "333",
+							"biz_opaque_callback_data": "order-42",
+							"conversation": {"id": "conv-1", "expiration_timestamp": "1700086400", "origin": {"type": "marketing"}},
+							"pricing": {"billable": true, "pricing_model": "CBP", "category": "marketing"}},
+						{"id": "wamid.out2", "status": "failed", "timestamp": "1700000005", "recipient_id": "444",
+							"errors": [{"code": 131049, "title": "Not delivered", "message": "Not delivered to maintain ecosystem health",
+								"error_data": {"details": "Marketing message limit"}}]}
+					]
+				}
+			}]
+		}]
+	}`)
+
+	event, err := whatsapp.ParseWebhookEvent(body)
+	require.NoError(t, err)
+
+	statuses := event.Statuses()
+	require.Len(t, statuses, 2)
+
+	sent := statuses[0]
+	assert.Equal(t, "wamid.out", sent.MessageID)
+	assert.Equal(t, "333", sent.RecipientID)
+	assert.Equal(t, "phone-123", sent.PhoneNumberID)
+	assert.Equal(t, whatsapp.DeliveryStatusSent, sent.Status)
+	assert.Equal(t, time.Unix(1700000000, 0), sent.Timestamp)
+	assert.Equal(t, "order-42", sent.CallbackData)
+	assert.Equal(t, "conv-1", sent.ConversationID)
+	assert.Equal(t, "marketing", sent.ConversationOrigin)
+	assert.Equal(t, time.Unix(1700086400, 0), sent.ConversationExpiresAt)
+	assert.True(t, sent.Billable)
+	assert.Equal(t, "CBP", sent.PricingModel)
+	assert.Equal(t, "marketing", sent.PricingCategory)
+	assert.Empty(t, sent.Errors)
+
+	failed := statuses[1]
+	assert.Equal(t, whatsapp.DeliveryStatusFailed, failed.Status)
+	assert.Empty(t, failed.CallbackData)
+	assert.Empty(t, failed.ConversationID)
+	assert.True(t, failed.ConversationExpiresAt.IsZero())
+	require.Len(t, failed.Errors, 1)
+	assert.Equal(t, 131049, failed.Errors[0].Code)
+	assert.Equal(t, "Marketing message limit", failed.Errors[0].ErrorData.Details)
+}
+
+func TestWebhookEvent_StatusesOnlyFromMessagesField(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{
+			"id": "123",
+			"changes": [{
+				"field": "message_template_status_update",
+				"value": {"statuses": [{"id": "wamid.other", "status": "sent", "timestamp": "1700000000"}]}
+			}, {
+				"field": "messages",
+				"value": {
+					"metadata": {"phone_number_id": "phone-123"},
+					"statuses": [{"id": "wamid.out", "status": "delivered", "timestamp": "1700000000"}]
+				}
+			}]
+		}]
+	}`)
+
+	event, err := whatsapp.ParseWebhookEvent(body)
+	require.NoError(t, err)
+	require.Len(t, event.Statuses(), 1)
+	assert.Equal(t, "wamid.out", event.Statuses()[0].MessageID)
+}
+
+func TestParseWebhookEvent_Order(t *testing.T) {
+	t.Parallel()
+	body := []byte(`{
+		"object": "whatsapp_business_account",
+		"entry": [{
+			"id": "123",
+			"changes": [{
+				"field": "messages",
+				"value": {
+					"messaging_product": "whatsapp",
+					"metadata": {"phone_number_id": "phone-123"},
+					"messages": [
+						{"from": "111", "id": "wamid.order", "timestamp": "1700000000", "type": "order",
+							"order": {"catalog_id": "catalog-1", "text": "Gift wrap please", "product_items": [
+								{"product_retailer_id": "SKU-1", "quantity": 2, "item_price": 12.99, "currency": "USD"},
+								{"product_retailer_id": "SKU-2", "quantity": 1, "item_price": 0.1, "currency": "USD"}
+							]}}
+					]
+				}
+			}]
+		}]
+	}`)
+
+	event, err := whatsapp.ParseWebhookEvent(body)
+	require.NoError(t, err)
+	require.Len(t, event.Messages, 1)
+
+	msg := event.Messages[0]
+	assert.Equal(t, whatsapp.MessageTypeOrder, msg.Type())
+	require.NotNil(t, msg.Order)
+	assert.Equal(t, "catalog-1", msg.Order.CatalogID)
+	assert.Equal(t, "Gift wrap please", msg.Order.Text)
+	require.Len(t, msg.Order.ProductItems, 2)
+	assert.Equal(t, "SKU-1", msg.Order.ProductItems[0].ProductRetailerID)
+	assert.Equal(t, 2, msg.Order.ProductItems[0].Quantity)
+	assert.Equal(t, int64(1299), msg.Order.ProductItems[0].UnitPrice())
+
+	total, currency, err := msg.Order.Total()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2608), total)
+	assert.Equal(t, "USD", currency)
+
+	mixed := whatsapp.Order{ProductItems: []whatsapp.OrderItem{
+		{Quantity: 1, ItemPrice: 1, Currency: "USD"},
+		{Quantity: 1, ItemPrice: 1, Currency: "EUR"},
+	}}
+	_, _, err = mixed.Total()
+	require.Error(t, err)
+}
+
+func TestParseWebhookEvent_InvalidJSON(t *testing.T) {
+	t.Parallel()
+	_, err := whatsapp.ParseWebhookEvent([]byte(`{invalid`))
+	require.Error(t, err)
+}
+
+func TestExtractMessages_TemplateButton(t *testing.T) {
+	t.Parallel()
+	payload := &whatsapp.WebhookPayload{
+		Entry: []whatsapp.WebhookEntry{{
+			Changes: []whatsapp.WebhookChange{{
+				Field: "messages",
+				Value: whatsapp.WebhookValue{
+					Messages: []whatsapp.WebhookMessage{{
+						From:   "15559876543",
+						ID:     "wamid.btn",
+						Type:   "button",
+						Button: &whatsapp.WebhookButton{Payload: "CONFIRM", Text: "Confirm"},
+					}},
+				},
+			}},
+		}},
+	}
+
+	messages := payload.ExtractMessages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "CONFIRM", messages[0].ButtonReplyID)
+	assert.Equal(t, "Confirm", messages[0].Text)
+}
+
 // --- ExtractMessages ---
 
 func TestExtractMessages_TextMessage(t *testing.T) {
@@ -0,0 +1,282 @@
+// Package whatsapptest provides an in-memory fake of the Meta Graph API for
+// hermetic tests of code built on the whatsapp client.
+//
+//	srv := whatsapptest.NewServer(t)
+//	client := srv.Client()
+//	id, err := client.SendTextMessage(ctx, srv.Account(), "15551234567", "Hi")
+//	req := srv.LastRequest() // POST /v21.0/123456789/messages
+//
+// Unmatched requests get canned success responses: message sends return a
+// generated message ID, product and catalog creates return a generated ID,
+// lists return no data and everything else returns {"success": true}. Use
+// Handle to return other responses, such as the preset errors.
+package whatsapptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/zerodha/logf"
+)
+
+// Identifiers of the account returned by Server.Account
+const (
+	PhoneID    = "123456789"
+	BusinessID = "987654321"
+	APIVersion = "v21.0"
+)
+
+// Request is a request received by the fake server
+type Request struct {
+	Method string
+	Path   string // e.g. "/v21.0/123456789/messages"
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Decode unmarshals the JSON request body into v
+func (r Request) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Response is a canned response returned by the fake server. A nil Body
+// writes an empty JSON object.
+type Response struct {
+	Status int // Defaults to 200
+	Header http.Header
+	Body   interface{} // Marshaled as JSON, or written as-is if a string or []byte
+}
+
+// ErrorResponse returns a Graph API error response with the given HTTP
+// status, error code and message
+func ErrorResponse(status, code int, message string) Response {
+	return Response{
+		Status: status,
+		Body: map[string]interface{}{
+			"error": map[string]interface{}{
+				"message":    message,
+				"type":       "OAuthException",
+				"code":       code,
+				"fbtrace_id": "whatsapptest-trace",
+			},
+		},
+	}
+}
+
+// Preset error responses for common Graph API failures
+var (
+	// ErrInvalidToken is returned for an expired or invalid access token (code 190)
+	ErrInvalidToken = ErrorResponse(http.StatusUnauthorized, 190, "Error validating access token: Session has expired")
+	// ErrPermissionDenied is returned when the token lacks a permission (code 10)
+	ErrPermissionDenied = ErrorResponse(http.StatusForbidden, 10, "Application does not have permission for this action")
+	// ErrNotFound is returned for an object that does not exist (code 100, subcode 33)
+	ErrNotFound = Response{
+		Status: http.StatusBadRequest,
+		Body: map[string]interface{}{
+			"error": map[string]interface{}{
+				"message":       "Unsupported get request. Object does not exist",
+				"type":          "GraphMethodException",
+				"code":          100,
+				"error_subcode": 33,
+				"fbtrace_id":    "whatsapptest-trace",
+			},
+		},
+	}
+	// ErrInvalidParameter is returned for a malformed request (code 100)
+	ErrInvalidParameter = ErrorResponse(http.StatusBadRequest, 100, "(#100) Invalid parameter")
+	// ErrRateLimited is returned when the application request limit is reached (code 4)
+	ErrRateLimited = ErrorResponse(http.StatusBadRequest, 4, "(#4) Application request limit reached")
+	// ErrThroughputLimit is returned when the Cloud API throughput limit is reached (code 130429)
+	ErrThroughputLimit = ErrorResponse(http.StatusTooManyRequests, 130429, "(#130429) Rate limit hit")
+	// ErrRecipientNotOnWhatsApp is returned for a recipient without WhatsApp (code 131026)
+	ErrRecipientNotOnWhatsApp = ErrorResponse(http.StatusBadRequest, 131026, "(#131026) Message undeliverable")
+	// ErrReengagement is returned for a free-form message outside the 24-hour window (code 131047)
+	ErrReengagement = ErrorResponse(http.StatusBadRequest, 131047, "(#131047) Re-engagement message")
+	// ErrTemplateNotFound is returned for a template that does not exist in the language (code 132001)
+	ErrTemplateNotFound = ErrorResponse(http.StatusNotFound, 132001, "(#132001) Template name does not exist in the translation")
+	// ErrServiceUnavailable is returned for a temporary Meta outage (code 2)
+	ErrServiceUnavailable = ErrorResponse(http.StatusServiceUnavailable, 2, "(#2) Service temporarily unavailable")
+)
+
+// route is a canned response for matching requests
+type route struct {
+	method string // Empty matches any method
+	suffix string // Matched against the end of the request path
+	resp   Response
+}
+
+// Server is a fake Graph API server that records every request it receives
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	routes   []route
+	requests []Request
+	seq      int
+}
+
+// NewServer starts a fake server, which is closed when the test finishes
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a whatsapp client that sends its requests to the server
+func (s *Server) Client(opts ...whatsapp.ClientOption) *whatsapp.Client {
+	log := logf.New(logf.Opts{Level: logf.ErrorLevel})
+	return whatsapp.New(log, append([]whatsapp.ClientOption{whatsapp.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// Account returns an account using the PhoneID, BusinessID and APIVersion constants
+func (s *Server) Account() *whatsapp.Account {
+	return &whatsapp.Account{
+		PhoneID:     PhoneID,
+		BusinessID:  BusinessID,
+		APIVersion:  APIVersion,
+		AccessToken: "whatsapptest-token",
+	}
+}
+
+// Handle returns resp for requests with the given method whose path ends with
+// pathSuffix, e.g. Handle(http.MethodPost, "/messages", ErrReengagement).
+// An empty method matches any method. Later calls take precedence.
+func (s *Server) Handle(method, pathSuffix string, resp Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = append(s.routes, route{method: method, suffix: pathSuffix, resp: resp})
+}
+
+// Requests returns the requests received so far, in order
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// LastRequest returns the most recent request, or a zero Request if none was received
+func (s *Server) LastRequest() Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		return Request{}
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+// Reset forgets the recorded requests and the responses set with Handle
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.routes = nil
+	s.requests = nil
+}
+
+// serveHTTP records a request and writes its response
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	resp, ok := s.match(r)
+	if !ok {
+		resp = s.defaultResponse(r, body)
+	}
+	s.mu.Unlock()
+
+	writeResponse(w, resp)
+}
+
+// match returns the most recently registered response matching r
+func (s *Server) match(r *http.Request) (Response, bool) {
+	for i := len(s.routes) - 1; i >= 0; i-- {
+		rt := s.routes[i]
+		if (rt.method == "" || rt.method == r.Method) && strings.HasSuffix(r.URL.Path, rt.suffix) {
+			return rt.resp, true
+		}
+	}
+	return Response{}, false
+}
+
+// defaultResponse returns the canned success response for r
+func (s *Server) defaultResponse(r *http.Request, body []byte) Response {
+	switch {
+	case r.Method == http.MethodGet:
+		return Response{Body: map[string]interface{}{"data": []interface{}{}}}
+	case strings.HasSuffix(r.URL.Path, "/messages"):
+		var msg struct {
+			To string `json:"to"`
+		}
+		_ = json.Unmarshal(body, &msg)
+		if msg.To == "" {
+			// Read receipts and typing indicators
+			return Response{Body: map[string]interface{}{"success": true}}
+		}
+		return Response{Body: map[string]interface{}{
+			"messaging_product": "whatsapp",
+			"contacts":          []map[string]string{{"input": msg.To, "wa_id": strings.TrimPrefix(msg.To, "+")}},
+			"messages":          []map[string]string{{"id": s.nextID("wamid.whatsapptest")}},
+		}}
+	case strings.HasSuffix(r.URL.Path, "/batch"):
+		return Response{Body: map[string]interface{}{"handles": []string{s.nextID("handle")}}}
+	case r.Method == http.MethodPost:
+		return Response{Body: map[string]interface{}{"id": s.nextID("id"), "success": true}}
+	default:
+		return Response{Body: map[string]interface{}{"success": true}}
+	}
+}
+
+// nextID returns a unique ID with the given prefix
+func (s *Server) nextID(prefix string) string {
+	s.seq++
+	return fmt.Sprintf("%s-%d", prefix, s.seq)
+}
+
+// writeResponse writes a canned response
+func writeResponse(w http.ResponseWriter, resp Response) {
+	var body []byte
+	switch b := resp.Body.(type) {
+	case nil:
+		body = []byte("{}")
+	case string:
+		body = []byte(b)
+	case []byte:
+		body = b
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			http.Error(w, fmt.Sprintf("whatsapptest: failed to marshal response: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	for key, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(key, v)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,87 @@
+package whatsapptest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/shridarpatil/whatomate/pkg/whatsapp"
+	"github.com/shridarpatil/whatomate/pkg/whatsapp/whatsapptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RecordsRequests(t *testing.T) {
+	t.Parallel()
+
+	srv := whatsapptest.NewServer(t)
+	client := srv.Client()
+
+	id, err := client.SendTextMessage(context.Background(), srv.Account(), "15551234567", "Hello")
+	require.NoError(t, err)
+	assert.NotEmpty(t, id)
+
+	require.Len(t, srv.Requests(), 1)
+	req := srv.LastRequest()
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/v21.0/123456789/messages", req.Path)
+	assert.Equal(t, "Bearer whatsapptest-token", req.Header.Get("Authorization"))
+
+	var body struct {
+		To   string `json:"to"`
+		Text struct {
+			Body string `json:"body"`
+		} `json:"text"`
+	}
+	require.NoError(t, req.Decode(&body))
+	assert.Equal(t, "15551234567", body.To)
+	assert.Equal(t, "Hello", body.Text.Body)
+}
+
+func TestServer_DefaultResponses(t *testing.T) {
+	t.Parallel()
+
+	srv := whatsapptest.NewServer(t)
+	client := srv.Client()
+	ctx := context.Background()
+
+	catalogID, err := client.CreateCatalog(ctx, srv.Account(), "Shop")
+	require.NoError(t, err)
+	assert.NotEmpty(t, catalogID)
+
+	products, err := client.ListCatalogProducts(ctx, srv.Account(), catalogID)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+
+	results, err := client.BatchUpsertProducts(ctx, srv.Account(), catalogID, []whatsapp.ProductInput{
+		{Name: "Mug", Price: 800, Currency: "USD", RetailerID: "SKU-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.NotEmpty(t, results[0].Handle)
+
+	require.NoError(t, client.MarkMessageRead(ctx, srv.Account(), "wamid.inbound"))
+}
+
+func TestServer_PresetErrors(t *testing.T) {
+	t.Parallel()
+
+	srv := whatsapptest.NewServer(t)
+	client := srv.Client()
+	srv.Handle(http.MethodPost, "/messages", whatsapptest.ErrReengagement)
+	srv.Handle(http.MethodGet, "", whatsapptest.ErrNotFound)
+
+	_, err := client.SendTextMessage(context.Background(), srv.Account(), "15551234567", "Hello")
+	var apiErr *whatsapp.GraphAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 131047, apiErr.Code)
+
+	_, err = client.GetProduct(context.Background(), srv.Account(), "prod-1")
+	assert.ErrorIs(t, err, whatsapp.ErrNotFound)
+
+	srv.Reset()
+	_, err = client.SendTextMessage(context.Background(), srv.Account(), "15551234567", "Hello")
+	require.NoError(t, err)
+	assert.Len(t, srv.Requests(), 1)
+}